@@ -0,0 +1,110 @@
+package todo
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNothingToUndo is returned when the user has no changes that can be undone.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrUndoConflict is returned when a change can't be undone because it was followed by another one.
+	ErrUndoConflict = errors.New("changed since the operation, can't undo")
+)
+
+const (
+	ActivityCreate  = "create"
+	ActivityUpdate  = "update"
+	ActivityDelete  = "delete"
+	ActivityRestore = "restore"
+	ActivityMove    = "move"
+)
+
+// Activity is an entry of the append-only log of changes made to a list or to one of its items.
+// ItemId is nil for changes of the list itself. Version is the version of the list or the item
+// after the change. Changes made within one transaction form a single operation that's undone at once.
+type Activity struct {
+	Id            int        `json:"id" db:"id"`
+	ActorId       *int       `json:"actor_id" db:"actor_id"`
+	ListId        int        `json:"list_id" db:"list_id"`
+	ItemId        *int       `json:"item_id" db:"item_id"`
+	Action        string     `json:"action" db:"action"`
+	Changes       Changes    `json:"changes,omitempty" db:"changes"`
+	Version       *int       `json:"version" db:"version"`
+	TransactionId int64      `json:"-" db:"transaction_id"`
+	Reverts       *int       `json:"reverts,omitempty" db:"reverts"`
+	UndoneAt      *time.Time `json:"undone_at,omitempty" db:"undone_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// FieldChange holds the values of a field before and after the change.
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Changes maps the names of the changed fields to their old and new values.
+// It's stored as a JSON object.
+type Changes map[string]FieldChange
+
+func (c Changes) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(c)
+}
+
+func (c *Changes) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(src, c)
+	case string:
+		return json.Unmarshal([]byte(src), c)
+	default:
+		return errors.New("unsupported type of changes")
+	}
+}
+
+// Revert returns the changes that restore the old values.
+func (c Changes) Revert() Changes {
+	reverted := make(Changes, len(c))
+	for field, change := range c {
+		reverted[field] = FieldChange{From: change.To, To: change.From}
+	}
+
+	return reverted
+}
+
+// Decode stores the new values of the fields in the struct pointed to by v,
+// matching the field names to its json tags.
+func (c Changes) Decode(v interface{}) error {
+	values := make(map[string]interface{}, len(c))
+	for field, change := range c {
+		values[field] = change.To
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// ActivityFilter pages through the log, newest entries first.
+type ActivityFilter struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// UndoInput selects the operation to undo by the id of one of its activity entries.
+// The latest operation of the user is undone by default. Undoing an undo redoes the operation.
+type UndoInput struct {
+	ActivityId *int `json:"activity_id"`
+}
@@ -0,0 +1,96 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+	OperationMove   = "move"
+)
+
+// MaxBulkOperations is the largest batch of a bulk request, the whole batch runs in one transaction.
+const MaxBulkOperations = 100
+
+// ErrInvalidOperation is returned when an operation of a bulk request is malformed.
+var ErrInvalidOperation = errors.New("invalid operation")
+
+const (
+	OperationStatusOk         = "ok"
+	OperationStatusFailed     = "failed"
+	OperationStatusRolledBack = "rolled_back"
+	OperationStatusSkipped    = "skipped"
+)
+
+// ItemOperation is a single operation of a bulk request. Create uses ListId and Item,
+// update uses Id and Update, move uses Id and Move and delete uses Id only.
+// Version makes updates and deletes conditional like the If-Match header does.
+type ItemOperation struct {
+	Op      string           `json:"op" binding:"required,oneof=create update delete move"`
+	Id      int              `json:"id"`
+	ListId  int              `json:"list_id"`
+	Item    *TodoItem        `json:"item"`
+	Update  *UpdateItemInput `json:"update"`
+	Move    *MoveItemInput   `json:"move"`
+	Version *int             `json:"version"`
+}
+
+func (o ItemOperation) Validate() error {
+	switch o.Op {
+	case OperationCreate:
+		if o.ListId == 0 || o.Item == nil {
+			return errors.New("create requires list_id and item")
+		}
+	case OperationUpdate:
+		if o.Id == 0 || o.Update == nil {
+			return errors.New("update requires id and update")
+		}
+		return o.Update.Validate()
+	case OperationDelete:
+		if o.Id == 0 {
+			return errors.New("delete requires id")
+		}
+	case OperationMove:
+		if o.Id == 0 || o.Move == nil {
+			return errors.New("move requires id and move")
+		}
+		return o.Move.Validate()
+	default:
+		return fmt.Errorf("unknown operation %q", o.Op)
+	}
+
+	return nil
+}
+
+// BulkItemsInput is a batch of item operations run in one transaction. If Atomic is set,
+// the first failed operation rolls back the whole batch, otherwise only the failed operations
+// are rolled back.
+type BulkItemsInput struct {
+	Atomic     bool            `json:"atomic"`
+	Operations []ItemOperation `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+func (i BulkItemsInput) Validate() error {
+	if len(i.Operations) > MaxBulkOperations {
+		return fmt.Errorf("%w: at most %d operations are allowed", ErrInvalidOperation, MaxBulkOperations)
+	}
+
+	for n, operation := range i.Operations {
+		if err := operation.Validate(); err != nil {
+			return fmt.Errorf("%w: operation %d: %s", ErrInvalidOperation, n, err)
+		}
+	}
+
+	return nil
+}
+
+// ItemOperationResult reports the outcome of an operation of a bulk request.
+// Id is the id of the created or changed item.
+type ItemOperationResult struct {
+	Status string `json:"status"`
+	Id     int    `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
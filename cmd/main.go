@@ -13,7 +13,9 @@ import (
 	"github.com/zhashkevych/todo-app"
 	"github.com/zhashkevych/todo-app/pkg/handler"
 	"github.com/zhashkevych/todo-app/pkg/repository"
+	"github.com/zhashkevych/todo-app/pkg/rpc"
 	"github.com/zhashkevych/todo-app/pkg/service"
+	"github.com/zhashkevych/todo-app/pkg/worker"
 )
 
 // @title Todo App API
@@ -38,29 +40,66 @@ func main() {
 		logrus.Fatalf("error loading env variables: %s", err.Error())
 	}
 
-	db, err := repository.NewPostgresDB(repository.Config{
+	dbConfig := repository.Config{
 		Host:     viper.GetString("db.host"),
 		Port:     viper.GetString("db.port"),
 		Username: viper.GetString("db.username"),
 		DBName:   viper.GetString("db.dbname"),
 		SSLMode:  viper.GetString("db.sslmode"),
 		Password: os.Getenv("DB_PASSWORD"),
-	})
+	}
+
+	db, err := repository.NewPostgresDB(dbConfig)
 	if err != nil {
 		logrus.Fatalf("failed to initialize db: %s", err.Error())
 	}
 
+	listener, err := repository.NewActivityListener(dbConfig)
+	if err != nil {
+		logrus.Fatalf("failed to listen to activity: %s", err.Error())
+	}
+
 	repos := repository.NewRepository(db)
-	services := service.NewService(repos)
+	services := service.NewService(repos, service.Config{
+		IdempotencyTTL:     viper.GetDuration("idempotency.ttl"),
+		UndoWindow:         viper.GetDuration("undo.window"),
+		WebhookTimeout:     viper.GetDuration("webhooks.timeout"),
+		WebhookMaxAttempts: viper.GetInt("webhooks.max_attempts"),
+		WebhookBackoff:     viper.GetDuration("webhooks.backoff"),
+		OutboxBackoff:      viper.GetDuration("outbox.backoff"),
+	})
 	handlers := handler.NewHandler(services)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	purger := worker.NewTrashPurger(services.Trash, viper.GetDuration("trash.retention"), viper.GetDuration("trash.purge_interval"))
+	go purger.Run(ctx)
+
+	dispatcher := worker.NewWebhookDispatcher(services.Webhook, viper.GetDuration("webhooks.dispatch_interval"))
+	go dispatcher.Run(ctx)
+
+	outboxRelay := worker.NewOutboxRelay(services.Outbox, viper.GetDuration("outbox.relay_interval"))
+	go outboxRelay.Run(ctx)
+
+	relay := worker.NewActivityRelay(listener, services.Stream)
+	go relay.Run(ctx)
+
 	srv := new(todo.Server)
 	go func() {
-		if err := srv.Run(viper.GetString("port"), handlers.InitRoutes()); err != nil {
+		if err := srv.Run(viper.GetString("port"), handlers.InitRoutes(handler.Deprecation{
+			Date:   viper.GetTime("api.v1_deprecated_at"),
+			Sunset: viper.GetTime("api.v1_sunset"),
+		})); err != nil {
 			logrus.Fatalf("error occured while running http server: %s", err.Error())
 		}
 	}()
 
+	grpcSrv := rpc.NewServer(services)
+	go func() {
+		if err := grpcSrv.Run(viper.GetString("grpc_port")); err != nil {
+			logrus.Fatalf("error occured while running grpc server: %s", err.Error())
+		}
+	}()
+
 	logrus.Print("TodoApp Started")
 
 	quit := make(chan os.Signal, 1)
@@ -69,10 +108,20 @@ func main() {
 
 	logrus.Print("TodoApp Shutting Down")
 
+	cancel()
+
 	if err := srv.Shutdown(context.Background()); err != nil {
 		logrus.Errorf("error occured on server shutting down: %s", err.Error())
 	}
 
+	if err := grpcSrv.Shutdown(context.Background()); err != nil {
+		logrus.Errorf("error occured on grpc server shutting down: %s", err.Error())
+	}
+
+	if err := listener.Close(); err != nil {
+		logrus.Errorf("error occured on activity listener close: %s", err.Error())
+	}
+
 	if err := db.Close(); err != nil {
 		logrus.Errorf("error occured on db connection close: %s", err.Error())
 	}
@@ -0,0 +1,45 @@
+package todo
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// errorCodes identify the errors of the package in responses of the API,
+// so that clients don't depend on the error messages. sql.ErrNoRows is
+// returned for resources that don't exist or aren't shared with the user.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrVersionMismatch, "version_mismatch"},
+	{ErrInvalidPatch, "invalid_patch"},
+	{ErrInvalidOperation, "invalid_operation"},
+	{ErrNothingToUndo, "nothing_to_undo"},
+	{ErrUndoConflict, "undo_conflict"},
+	{ErrIdempotencyKeyReused, "idempotency_key_reused"},
+	{ErrRequestInProgress, "request_in_progress"},
+	{sql.ErrNoRows, "not_found"},
+}
+
+// ErrorCode returns the code of the error of the package err wraps or an empty string if there is none.
+func ErrorCode(err error) string {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+
+	return ""
+}
+
+// ErrorByCode returns the error of the package with the code or nil if there is none.
+func ErrorByCode(code string) error {
+	for _, c := range errorCodes {
+		if c.code == code {
+			return c.err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package todo
+
+const (
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+	FormatTodoTxt  = "todotxt"
+)
+
+// ListExport is a list with all of its items, subtasks and archived items included.
+// Tags maps the ids of the tagged items to the names of the user's tags on them.
+type ListExport struct {
+	TodoList
+	Items []TodoItem       `json:"items"`
+	Tags  map[int][]string `json:"tags,omitempty"`
+}
+
+// ExportInput selects the format of an export, JSON by default.
+type ExportInput struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv markdown todotxt"`
+}
+
+// ImportInput selects the format of an uploaded file, which is otherwise inferred from its name.
+// A dry run validates the file and reports what would be created without creating anything.
+type ImportInput struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv markdown todotxt"`
+	DryRun bool   `form:"dry_run"`
+}
+
+// ImportError reports a row of an uploaded file that can't be imported. Row is the line
+// of the row in the file, counting records rather than lines for CSV, or zero for JSON.
+type ImportError struct {
+	Row     int    `json:"row,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportedList is a list created by an import, or one that would be created by a dry run.
+type ImportedList struct {
+	Id    int    `json:"id,omitempty"`
+	Title string `json:"title"`
+	Items int    `json:"items"`
+}
+
+type ImportResult struct {
+	DryRun bool           `json:"dry_run"`
+	Lists  []ImportedList `json:"lists"`
+	Errors []ImportError  `json:"errors"`
+}
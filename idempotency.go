@@ -0,0 +1,48 @@
+package todo
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	ErrIdempotencyKeyReused = errors.New("idempotency key has already been used for a different request")
+	ErrRequestInProgress    = errors.New("request with this idempotency key is still in progress")
+)
+
+// IdempotencyKey stores the response to the first request made with a key.
+// StatusCode is nil while that request is being processed.
+type IdempotencyKey struct {
+	Key             string          `db:"key"`
+	RequestHash     string          `db:"request_hash"`
+	StatusCode      *int            `db:"status_code"`
+	ResponseHeaders ResponseHeaders `db:"response_headers"`
+	ResponseBody    []byte          `db:"response_body"`
+}
+
+// ResponseHeaders maps the names of the stored response headers to their values.
+// It's stored as a JSON object.
+type ResponseHeaders map[string]string
+
+func (h ResponseHeaders) Value() (driver.Value, error) {
+	if len(h) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(h)
+}
+
+func (h *ResponseHeaders) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(src, h)
+	case string:
+		return json.Unmarshal([]byte(src), h)
+	default:
+		return errors.New("unsupported type of response headers")
+	}
+}
@@ -0,0 +1,18 @@
+package todo
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent is a domain event written to the outbox in the transaction of the change
+// that caused it. Its payload is the same WebhookEvent that webhooks receive.
+type OutboxEvent struct {
+	Id        int             `json:"id" db:"id"`
+	Event     string          `json:"event" db:"event"`
+	ListId    int             `json:"list_id" db:"list_id"`
+	ItemId    *int            `json:"item_id" db:"item_id"`
+	Payload   json.RawMessage `json:"payload" db:"payload"`
+	Attempts  int             `json:"attempts" db:"attempts"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,22 @@
+package todo
+
+import "errors"
+
+// ErrInvalidPatch is returned when a patch document can't be applied to a resource.
+var ErrInvalidPatch = errors.New("invalid patch")
+
+type PatchType int
+
+const (
+	// MergePatch is an RFC 7396 JSON Merge Patch document.
+	MergePatch PatchType = iota
+	// JSONPatch is an RFC 6902 JSON Patch document.
+	JSONPatch
+)
+
+// Patch describes changes to the fields of a list or an item. Fields set to null
+// or removed by the patch are reset to their zero values.
+type Patch struct {
+	Type     PatchType
+	Document []byte
+}
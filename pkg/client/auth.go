@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/zhashkevych/todo-app"
+)
+
+// tokenLeeway is how long before its expiration a token is replaced with a new one.
+const tokenLeeway = time.Minute
+
+type signInInput struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type idResponse struct {
+	Id int `json:"id"`
+}
+
+type tokenResponse struct {
+	Token string `json:"token"`
+}
+
+// SignUp creates the user and returns their id. The client isn't signed in as the user.
+func (c *Client) SignUp(ctx context.Context, user todo.User) (int, error) {
+	var resp idResponse
+	err := c.do(ctx, request{method: http.MethodPost, path: "/auth/sign-up", body: user}, &resp)
+
+	return resp.Id, err
+}
+
+// SignIn replaces the credentials of the client and gets a token with them.
+func (c *Client) SignIn(ctx context.Context, username, password string) error {
+	c.mu.Lock()
+	c.username, c.password, c.token = username, password, ""
+	c.mu.Unlock()
+
+	_, err := c.getToken(ctx, true)
+	return err
+}
+
+// getToken returns the token of the client, signing in if there is none, it is about
+// to expire or refresh is set. Tokens given without credentials are returned as is.
+func (c *Client) getToken(ctx context.Context, refresh bool) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.username == "" {
+		return c.token, nil
+	}
+
+	if !refresh && c.token != "" && !expiring(c.token) {
+		return c.token, nil
+	}
+
+	var resp tokenResponse
+	input := signInInput{Username: c.username, Password: c.password}
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/auth/sign-in", body: input}, &resp); err != nil {
+		return "", err
+	}
+	c.token = resp.Token
+
+	return c.token, nil
+}
+
+func (c *Client) canSignIn() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.username != ""
+}
+
+// expiring reports whether the token expires within tokenLeeway. The signature isn't
+// verified, the client only needs to know when to get a new token. Tokens that can't be
+// parsed are used until the server rejects them.
+func expiring(token string) bool {
+	var claims jwt.StandardClaims
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, &claims); err != nil || claims.ExpiresAt == 0 {
+		return false
+	}
+
+	return time.Unix(claims.ExpiresAt, 0).Before(time.Now().Add(tokenLeeway))
+}
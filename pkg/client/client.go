@@ -0,0 +1,229 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	apiPrefix = "/api/v2"
+
+	authorizationHeader  = "Authorization"
+	idempotencyKeyHeader = "Idempotency-Key"
+	ifMatchHeader        = "If-Match"
+
+	defaultBackoff = 100 * time.Millisecond
+	maxBackoff     = 10 * time.Second
+)
+
+type Config struct {
+	// BaseURL is the scheme and the host of the API, e.g. http://localhost:8000.
+	BaseURL string
+
+	// Username and Password are used to sign in when the client has no token
+	// or its token has expired. Token is used as is without them.
+	Username string
+	Password string
+	Token    string
+
+	// HTTPClient is http.DefaultClient by default.
+	HTTPClient *http.Client
+
+	// MaxRetries is the number of times a request is retried after a network error,
+	// 429 or 502-504 response. The delay before the first retry is Backoff,
+	// 100ms by default, and it is doubled with every next one.
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// Client calls the v2 REST API on behalf of a user.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+
+	mu       sync.Mutex
+	username string
+	password string
+	token    string
+}
+
+func NewClient(cfg Config) *Client {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	backoff := cfg.Backoff
+	if backoff == 0 {
+		backoff = defaultBackoff
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		httpClient: httpClient,
+		maxRetries: cfg.MaxRetries,
+		backoff:    backoff,
+		username:   cfg.Username,
+		password:   cfg.Password,
+		token:      cfg.Token,
+	}
+}
+
+// request describes a call to the API. The body is encoded once, so it can be sent again on retries.
+type request struct {
+	method  string
+	path    string
+	query   url.Values
+	body    interface{}
+	version *int
+	auth    bool
+}
+
+// do sends the request and decodes the response into out, unless it is nil.
+// Requests of a signed in user are sent again with a new token once if the token is rejected.
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return err
+		}
+	}
+
+	header := make(http.Header)
+	if req.body != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	if req.version != nil {
+		header.Set(ifMatchHeader, fmt.Sprintf(`"%d"`, *req.version))
+	}
+	if req.method == http.MethodPost && req.auth {
+		// retries of a POST request are replayed by the server rather than applied again
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return err
+		}
+		header.Set(idempotencyKeyHeader, key)
+	}
+
+	if !req.auth {
+		return c.send(ctx, req, header, body, out)
+	}
+
+	token, err := c.getToken(ctx, false)
+	if err != nil {
+		return err
+	}
+	header.Set(authorizationHeader, "Bearer "+token)
+
+	err = c.send(ctx, req, header, body, out)
+	if !isUnauthorized(err) || !c.canSignIn() {
+		return err
+	}
+
+	if token, err = c.getToken(ctx, true); err != nil {
+		return err
+	}
+	header.Set(authorizationHeader, "Bearer "+token)
+
+	return c.send(ctx, req, header, body, out)
+}
+
+// send makes the request retrying it on transient failures with exponential backoff.
+func (c *Client) send(ctx context.Context, req request, header http.Header, body []byte, out interface{}) error {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, req.method, u, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		httpReq.Header = header.Clone()
+
+		resp, err := c.httpClient.Do(httpReq)
+		if err == nil {
+			if !retryable(resp.StatusCode) || attempt >= c.maxRetries {
+				return decodeResponse(resp, out)
+			}
+			drain(resp)
+		} else if ctx.Err() != nil || attempt >= c.maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(retryDelay(c.backoff, attempt+1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func decodeResponse(resp *http.Response, out interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// drain reads the rest of the body, so the connection can be reused.
+func drain(resp *http.Response) {
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay doubles base with every attempt after the first one, up to maxBackoff.
+func retryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+
+	return delay
+}
+
+func newIdempotencyKey() (string, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
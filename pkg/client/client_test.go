@@ -0,0 +1,249 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/handler"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestServer(services *service.Service) *httptest.Server {
+	return httptest.NewServer(handler.NewHandler(services).InitRoutes(handler.Deprecation{}))
+}
+
+func TestClient_signIn(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization)
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+		ExpiresAt: time.Now().Add(-time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		cfg           Config
+		mockBehavior  mockBehavior
+		expectedError string
+	}{
+		{
+			name: "Acquire Once",
+			cfg:  Config{Username: "user", Password: "qwerty"},
+			mockBehavior: func(r *service_mocks.MockAuthorization) {
+				r.EXPECT().GenerateToken("user", "qwerty").Return("token", nil)
+				r.EXPECT().ParseToken("token").Return(1, nil).Times(2)
+			},
+		},
+		{
+			name: "Refresh Rejected",
+			cfg:  Config{Username: "user", Password: "qwerty", Token: "old"},
+			mockBehavior: func(r *service_mocks.MockAuthorization) {
+				r.EXPECT().ParseToken("old").Return(0, errors.New("token is expired"))
+				r.EXPECT().GenerateToken("user", "qwerty").Return("new", nil)
+				r.EXPECT().ParseToken("new").Return(1, nil).Times(2)
+			},
+		},
+		{
+			name: "Refresh Expiring",
+			cfg:  Config{Username: "user", Password: "qwerty", Token: expired},
+			mockBehavior: func(r *service_mocks.MockAuthorization) {
+				r.EXPECT().GenerateToken("user", "qwerty").Return("new", nil)
+				r.EXPECT().ParseToken("new").Return(1, nil).Times(2)
+			},
+		},
+		{
+			name: "Token Without Credentials",
+			cfg:  Config{Token: "old"},
+			mockBehavior: func(r *service_mocks.MockAuthorization) {
+				r.EXPECT().ParseToken("old").Return(0, errors.New("token is expired"))
+			},
+			expectedError: "todo api: 401 Unauthorized: token is expired",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			test.mockBehavior(auth)
+
+			lists := service_mocks.NewMockTodoList(c)
+			lists.EXPECT().GetById(1, 1).Return(todo.TodoList{Id: 1, Title: "Shopping"}, nil).AnyTimes()
+
+			server := newTestServer(&service.Service{Authorization: auth, TodoList: lists})
+			defer server.Close()
+
+			test.cfg.BaseURL = server.URL
+			client := NewClient(test.cfg)
+
+			// Make Requests
+			var err error
+			for i := 0; i < 2 && err == nil; i++ {
+				_, err = client.GetListById(context.Background(), 1)
+			}
+
+			// Assert
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClient_retries(t *testing.T) {
+	// Init Test Table
+	tests := []struct {
+		name               string
+		failures           int
+		maxRetries         int
+		expectedAttempts   int
+		expectedStatusCode int
+	}{
+		{
+			name:             "Retried",
+			failures:         2,
+			maxRetries:       2,
+			expectedAttempts: 3,
+		},
+		{
+			name:               "Out Of Retries",
+			failures:           2,
+			maxRetries:         1,
+			expectedAttempts:   2,
+			expectedStatusCode: 503,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil).AnyTimes()
+
+			lists := service_mocks.NewMockTodoList(c)
+			lists.EXPECT().Create(1, todo.TodoList{Title: "Shopping"}).Return(1, nil).AnyTimes()
+			lists.EXPECT().GetById(1, 1).Return(todo.TodoList{Id: 1, Title: "Shopping"}, nil).AnyTimes()
+
+			idempotency := service_mocks.NewMockIdempotency(c)
+			idempotency.EXPECT().Begin(1, gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			idempotency.EXPECT().Complete(1, gomock.Any(), 201, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			router := handler.NewHandler(&service.Service{Authorization: auth, TodoList: lists, Idempotency: idempotency}).
+				InitRoutes(handler.Deprecation{})
+
+			// the server is unavailable for the first requests
+			var mu sync.Mutex
+			var keys []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				keys = append(keys, r.Header.Get("Idempotency-Key"))
+				attempt := len(keys)
+				mu.Unlock()
+
+				if attempt <= test.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				router.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			client := NewClient(Config{
+				BaseURL:    server.URL,
+				Token:      "token",
+				MaxRetries: test.maxRetries,
+				Backoff:    time.Millisecond,
+			})
+
+			// Make Request
+			list, err := client.CreateList(context.Background(), todo.TodoList{Title: "Shopping"})
+
+			// Assert
+			assert.Equal(t, test.expectedAttempts, len(keys))
+			for _, key := range keys {
+				assert.Equal(t, keys[0], key)
+			}
+			assert.NotEmpty(t, keys[0])
+
+			if test.expectedStatusCode != 0 {
+				var apiErr *Error
+				assert.True(t, errors.As(err, &apiErr))
+				assert.Equal(t, test.expectedStatusCode, apiErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, todo.TodoList{Id: 1, Title: "Shopping"}, list)
+			}
+		})
+	}
+}
+
+func TestClient_canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, Token: "token", MaxRetries: 5, Backoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetListById(ctx, 1)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestError_Unwrap(t *testing.T) {
+	// Init Test Table
+	tests := []struct {
+		name          string
+		err           *Error
+		expectedError error
+	}{
+		{
+			name:          "Version Mismatch",
+			err:           &Error{StatusCode: 412, Message: "resource has been modified", Code: "version_mismatch"},
+			expectedError: todo.ErrVersionMismatch,
+		},
+		{
+			name:          "Request In Progress",
+			err:           &Error{StatusCode: 409, Message: "request with this idempotency key is still in progress", Code: "request_in_progress"},
+			expectedError: todo.ErrRequestInProgress,
+		},
+		{
+			name:          "Wrapped",
+			err:           &Error{StatusCode: 400, Message: "invalid patch: invalid due_date", Code: "invalid_patch"},
+			expectedError: todo.ErrInvalidPatch,
+		},
+		{
+			name: "Other",
+			err:  &Error{StatusCode: 500, Message: "something went wrong"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedError, test.err.Unwrap())
+		})
+	}
+}
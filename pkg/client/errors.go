@@ -0,0 +1,53 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/zhashkevych/todo-app"
+)
+
+// Error is returned for responses with an error status. It wraps the error of the
+// todo package the server responded with the code of, if any, so it can be checked
+// with errors.Is(err, todo.ErrVersionMismatch) the way the server checks it.
+// Missing resources are matched by errors.Is(err, sql.ErrNoRows).
+type Error struct {
+	StatusCode int
+	Message    string
+	Code       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("todo api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return todo.ErrorByCode(e.Code)
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func newError(resp *http.Response) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var errResp errorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Message == "" {
+		// not a response of the API, e.g. of a proxy in front of it
+		errResp.Message = string(body)
+	}
+
+	return &Error{StatusCode: resp.StatusCode, Message: errResp.Message, Code: errResp.Code}
+}
+
+func isUnauthorized(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.StatusCode == http.StatusUnauthorized
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/zhashkevych/todo-app"
+)
+
+func (c *Client) CreateItem(ctx context.Context, listId int, item todo.TodoItem) (todo.TodoItem, error) {
+	var created todo.TodoItem
+	err := c.do(ctx, request{method: http.MethodPost, path: listPath(listId) + "/items/", body: item, auth: true}, &created)
+
+	return created, err
+}
+
+// GetAllItems returns the items of the list, requesting every page of them.
+func (c *Client) GetAllItems(ctx context.Context, listId int, filter todo.ItemFilter) ([]todo.TodoItem, error) {
+	query := make(url.Values)
+	if filter.Tag != "" {
+		query.Set("tag", filter.Tag)
+	}
+	if filter.IncludeArchived {
+		query.Set("include_archived", "true")
+	}
+	if filter.Sort != "" {
+		query.Set("sort", filter.Sort)
+	}
+
+	items := []todo.TodoItem{}
+	for {
+		var page struct {
+			Data       []todo.TodoItem `json:"data"`
+			Pagination pagination      `json:"pagination"`
+		}
+		if err := c.getPage(ctx, listPath(listId)+"/items/", query, len(items), &page); err != nil {
+			return nil, err
+		}
+
+		items = append(items, page.Data...)
+		if len(page.Data) == 0 || len(items) >= page.Pagination.Total {
+			return items, nil
+		}
+	}
+}
+
+func (c *Client) GetItemById(ctx context.Context, itemId int) (todo.TodoItem, error) {
+	var item todo.TodoItem
+	err := c.do(ctx, request{method: http.MethodGet, path: itemPath(itemId), auth: true}, &item)
+
+	return item, err
+}
+
+// UpdateItem changes the item and returns it. If version is set, the item is changed
+// only if it still has the version, otherwise todo.ErrVersionMismatch is returned.
+func (c *Client) UpdateItem(ctx context.Context, itemId int, input todo.UpdateItemInput, version *int) (todo.TodoItem, error) {
+	var item todo.TodoItem
+	err := c.do(ctx, request{method: http.MethodPut, path: itemPath(itemId), body: input, version: version, auth: true}, &item)
+
+	return item, err
+}
+
+// DeleteItem moves the item to the trash. Version is checked like by UpdateItem.
+func (c *Client) DeleteItem(ctx context.Context, itemId int, version *int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: itemPath(itemId), version: version, auth: true}, nil)
+}
+
+func itemPath(itemId int) string {
+	return fmt.Sprintf("%s/items/%d", apiPrefix, itemId)
+}
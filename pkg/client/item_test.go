@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"testing"
+)
+
+func TestClient_CreateItem(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem, i *service_mocks.MockIdempotency)
+
+	tests := []struct {
+		name          string
+		inputItem     todo.TodoItem
+		mockBehavior  mockBehavior
+		expectedItem  todo.TodoItem
+		expectedError string
+	}{
+		{
+			name:      "Ok",
+			inputItem: todo.TodoItem{Title: "Bread"},
+			mockBehavior: func(r *service_mocks.MockTodoItem, i *service_mocks.MockIdempotency) {
+				i.EXPECT().Begin(1, gomock.Any(), gomock.Any()).Return(nil, nil)
+				r.EXPECT().Create(1, 1, todo.TodoItem{Title: "Bread"}).Return(2, nil)
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{Id: 2, Title: "Bread", ListId: 1, Version: 1}, nil)
+				i.EXPECT().Complete(1, gomock.Any(), 201, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedItem: todo.TodoItem{Id: 2, Title: "Bread", ListId: 1, Version: 1},
+		},
+		{
+			name:      "Invalid Input",
+			inputItem: todo.TodoItem{},
+			mockBehavior: func(r *service_mocks.MockTodoItem, i *service_mocks.MockIdempotency) {
+				i.EXPECT().Begin(1, gomock.Any(), gomock.Any()).Return(nil, nil)
+				i.EXPECT().Complete(1, gomock.Any(), 400, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: "todo api: 400 Bad Request: Key: 'TodoItem.Title' Error:Field validation for 'Title' failed on the 'required' tag",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil)
+
+			repo := service_mocks.NewMockTodoItem(c)
+			idempotency := service_mocks.NewMockIdempotency(c)
+			test.mockBehavior(repo, idempotency)
+
+			server := newTestServer(&service.Service{Authorization: auth, TodoItem: repo, Idempotency: idempotency})
+			defer server.Close()
+
+			client := NewClient(Config{BaseURL: server.URL, Token: "token"})
+
+			// Make Request
+			item, err := client.CreateItem(context.Background(), 1, test.inputItem)
+
+			// Assert
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedItem, item)
+			}
+		})
+	}
+}
+
+func TestClient_GetAllItems(t *testing.T) {
+	// Init Dependencies
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	items := []todo.TodoItem{{Id: 1, Title: "Bread", ListId: 1}, {Id: 2, Title: "Milk", ListId: 1}}
+	filter := todo.ItemFilter{Tag: "home", Sort: "updated_at"}
+
+	auth := service_mocks.NewMockAuthorization(c)
+	auth.EXPECT().ParseToken("token").Return(1, nil)
+
+	repo := service_mocks.NewMockTodoItem(c)
+	repo.EXPECT().GetAll(1, 1, todo.ItemFilter{Tag: "home", Sort: "updated_at", Page: todo.Page{Limit: 100}}).Return(items, 2, nil)
+
+	server := newTestServer(&service.Service{Authorization: auth, TodoItem: repo})
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, Token: "token"})
+
+	// Make Request
+	got, err := client.GetAllItems(context.Background(), 1, filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, items, got)
+}
+
+func TestClient_DeleteItem(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem)
+
+	version := 2
+
+	tests := []struct {
+		name          string
+		version       *int
+		mockBehavior  mockBehavior
+		expectedError error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Delete(1, 2, nil).Return(nil)
+			},
+		},
+		{
+			name:    "Version Mismatch",
+			version: &version,
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Delete(1, 2, &version).Return(todo.ErrVersionMismatch)
+			},
+			expectedError: todo.ErrVersionMismatch,
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Delete(1, 2, nil).Return(sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil)
+
+			repo := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(repo)
+
+			server := newTestServer(&service.Service{Authorization: auth, TodoItem: repo})
+			defer server.Close()
+
+			client := NewClient(Config{BaseURL: server.URL, Token: "token"})
+
+			// Make Request
+			err := client.DeleteItem(context.Background(), 2, test.version)
+
+			// Assert
+			if test.expectedError != nil {
+				assert.True(t, errors.Is(err, test.expectedError))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
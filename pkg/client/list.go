@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/zhashkevych/todo-app"
+)
+
+// pageSize is the largest page of a collection the server responds with.
+const pageSize = 100
+
+type pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
+}
+
+func (c *Client) CreateList(ctx context.Context, list todo.TodoList) (todo.TodoList, error) {
+	var created todo.TodoList
+	err := c.do(ctx, request{method: http.MethodPost, path: apiPrefix + "/lists/", body: list, auth: true}, &created)
+
+	return created, err
+}
+
+// GetAllLists returns the lists of the user, requesting every page of them.
+func (c *Client) GetAllLists(ctx context.Context, filter todo.ListFilter) ([]todo.TodoList, error) {
+	query := make(url.Values)
+	if filter.IncludeArchived {
+		query.Set("include_archived", "true")
+	}
+	if filter.Sort != "" {
+		query.Set("sort", filter.Sort)
+	}
+
+	lists := []todo.TodoList{}
+	for {
+		var page struct {
+			Data       []todo.TodoList `json:"data"`
+			Pagination pagination      `json:"pagination"`
+		}
+		if err := c.getPage(ctx, apiPrefix+"/lists/", query, len(lists), &page); err != nil {
+			return nil, err
+		}
+
+		lists = append(lists, page.Data...)
+		if len(page.Data) == 0 || len(lists) >= page.Pagination.Total {
+			return lists, nil
+		}
+	}
+}
+
+func (c *Client) GetListById(ctx context.Context, listId int) (todo.TodoList, error) {
+	var list todo.TodoList
+	err := c.do(ctx, request{method: http.MethodGet, path: listPath(listId), auth: true}, &list)
+
+	return list, err
+}
+
+// UpdateList changes the list and returns it. If version is set, the list is changed
+// only if it still has the version, otherwise todo.ErrVersionMismatch is returned.
+func (c *Client) UpdateList(ctx context.Context, listId int, input todo.UpdateListInput, version *int) (todo.TodoList, error) {
+	var list todo.TodoList
+	err := c.do(ctx, request{method: http.MethodPut, path: listPath(listId), body: input, version: version, auth: true}, &list)
+
+	return list, err
+}
+
+// DeleteList moves the list to the trash. Version is checked like by UpdateList.
+func (c *Client) DeleteList(ctx context.Context, listId int, version *int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: listPath(listId), version: version, auth: true}, nil)
+}
+
+// getPage requests the page of the collection starting at offset.
+func (c *Client) getPage(ctx context.Context, path string, query url.Values, offset int, out interface{}) error {
+	pageQuery := make(url.Values)
+	for key, values := range query {
+		pageQuery[key] = values
+	}
+	pageQuery.Set("limit", strconv.Itoa(pageSize))
+	pageQuery.Set("offset", strconv.Itoa(offset))
+
+	return c.do(ctx, request{method: http.MethodGet, path: path, query: pageQuery, auth: true}, out)
+}
+
+func listPath(listId int) string {
+	return fmt.Sprintf("%s/lists/%d", apiPrefix, listId)
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"testing"
+)
+
+func TestClient_GetAllLists(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoList, lists []todo.TodoList)
+
+	tests := []struct {
+		name          string
+		count         int
+		filter        todo.ListFilter
+		mockBehavior  mockBehavior
+		expectedError string
+	}{
+		{
+			name:   "Pages",
+			count:  150,
+			filter: todo.ListFilter{IncludeArchived: true, Sort: "-created_at"},
+			mockBehavior: func(r *service_mocks.MockTodoList, lists []todo.TodoList) {
+				filter := todo.ListFilter{IncludeArchived: true, Sort: "-created_at", Page: todo.Page{Limit: 100}}
+				r.EXPECT().GetAll(1, filter).Return(lists[:100], 150, nil)
+				filter.Offset = 100
+				r.EXPECT().GetAll(1, filter).Return(lists[100:], 150, nil)
+			},
+		},
+		{
+			name:  "Empty",
+			count: 0,
+			mockBehavior: func(r *service_mocks.MockTodoList, lists []todo.TodoList) {
+				r.EXPECT().GetAll(1, todo.ListFilter{Page: todo.Page{Limit: 100}}).Return(lists, 0, nil)
+			},
+		},
+		{
+			name:  "Service Failure",
+			count: 0,
+			mockBehavior: func(r *service_mocks.MockTodoList, lists []todo.TodoList) {
+				r.EXPECT().GetAll(1, todo.ListFilter{Page: todo.Page{Limit: 100}}).Return(nil, 0, errors.New("something went wrong"))
+			},
+			expectedError: "todo api: 500 Internal Server Error: something went wrong",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			lists := make([]todo.TodoList, test.count)
+			for i := range lists {
+				lists[i] = todo.TodoList{Id: i + 1, Title: "Shopping"}
+			}
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil).AnyTimes()
+
+			repo := service_mocks.NewMockTodoList(c)
+			test.mockBehavior(repo, lists)
+
+			server := newTestServer(&service.Service{Authorization: auth, TodoList: repo})
+			defer server.Close()
+
+			client := NewClient(Config{BaseURL: server.URL, Token: "token"})
+
+			// Make Request
+			got, err := client.GetAllLists(context.Background(), test.filter)
+
+			// Assert
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, lists, got)
+			}
+		})
+	}
+}
+
+func TestClient_UpdateList(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoList)
+
+	title := "Groceries"
+	version := 3
+	input := todo.UpdateListInput{Title: &title}
+
+	tests := []struct {
+		name           string
+		version        *int
+		mockBehavior   mockBehavior
+		expectedList   todo.TodoList
+		expectedStatus int
+		expectedError  error
+	}{
+		{
+			name:    "Ok",
+			version: &version,
+			mockBehavior: func(r *service_mocks.MockTodoList) {
+				r.EXPECT().Update(1, 1, input, &version).Return(nil)
+				r.EXPECT().GetById(1, 1).Return(todo.TodoList{Id: 1, Title: "Groceries", Version: 4}, nil)
+			},
+			expectedList: todo.TodoList{Id: 1, Title: "Groceries", Version: 4},
+		},
+		{
+			name:    "Version Mismatch",
+			version: &version,
+			mockBehavior: func(r *service_mocks.MockTodoList) {
+				r.EXPECT().Update(1, 1, input, &version).Return(todo.ErrVersionMismatch)
+			},
+			expectedStatus: 412,
+			expectedError:  todo.ErrVersionMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil)
+
+			repo := service_mocks.NewMockTodoList(c)
+			test.mockBehavior(repo)
+
+			server := newTestServer(&service.Service{Authorization: auth, TodoList: repo})
+			defer server.Close()
+
+			client := NewClient(Config{BaseURL: server.URL, Token: "token"})
+
+			// Make Request
+			list, err := client.UpdateList(context.Background(), 1, input, test.version)
+
+			// Assert
+			if test.expectedError != nil {
+				var apiErr *Error
+				assert.True(t, errors.Is(err, test.expectedError))
+				assert.True(t, errors.As(err, &apiErr))
+				assert.Equal(t, test.expectedStatus, apiErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedList, list)
+			}
+		})
+	}
+}
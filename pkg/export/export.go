@@ -0,0 +1,337 @@
+// Package export writes lists with their items in the formats users can keep as a backup
+// or paste into documents: JSON, CSV, Markdown checklists and todo.txt, and reads them back
+// along with similar files exported by other apps.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhashkevych/todo-app"
+)
+
+// todoTxtDueKey is the common todo.txt extension for due dates.
+const todoTxtDueKey = "due:"
+
+// Encoder writes a sequence of lists. Close has to be called after the last list
+// to complete the output.
+type Encoder interface {
+	Encode(list todo.ListExport) error
+	Close() error
+}
+
+// NewEncoder returns an encoder writing to w in the given format.
+func NewEncoder(w io.Writer, format string) (Encoder, error) {
+	switch format {
+	case todo.FormatJSON:
+		return &jsonEncoder{w: w}, nil
+	case todo.FormatCSV:
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	case todo.FormatMarkdown:
+		return &markdownEncoder{w: w}, nil
+	case todo.FormatTodoTxt:
+		return &todoTxtEncoder{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// Encode writes a single list. Unlike an encoder, which writes a JSON array,
+// it writes the list as a JSON object.
+func Encode(w io.Writer, format string, list todo.ListExport) error {
+	if format == todo.FormatJSON {
+		return json.NewEncoder(w).Encode(list)
+	}
+
+	encoder, err := NewEncoder(w, format)
+	if err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(list); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// ContentType returns the media type of the format.
+func ContentType(format string) string {
+	switch format {
+	case todo.FormatCSV:
+		return "text/csv; charset=utf-8"
+	case todo.FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case todo.FormatTodoTxt:
+		return "text/plain; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Extension returns the file name extension of the format.
+func Extension(format string) string {
+	switch format {
+	case todo.FormatCSV:
+		return "csv"
+	case todo.FormatMarkdown:
+		return "md"
+	case todo.FormatTodoTxt:
+		return "txt"
+	default:
+		return "json"
+	}
+}
+
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(list todo.ListExport) error {
+	separator := ","
+	if e.count == 0 {
+		separator = "["
+	}
+	e.count++
+
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+
+	return json.NewEncoder(e.w).Encode(list)
+}
+
+func (e *jsonEncoder) Close() error {
+	end := "]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+var csvHeader = []string{"list_id", "list_title", "list_description", "list_archived", "item_id", "parent_id",
+	"title", "description", "done", "archived", "created_at", "updated_at", "completed_at", "due_date"}
+
+// csvEncoder writes a row per item, preceded by the list's columns. Lists without
+// items are written as a single row with empty item columns.
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvEncoder) Encode(list todo.ListExport) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	listColumns := []string{strconv.Itoa(list.Id), list.Title, list.Description, strconv.FormatBool(list.Archived)}
+	if len(list.Items) == 0 {
+		return e.w.Write(append(listColumns, make([]string, len(csvHeader)-len(listColumns))...))
+	}
+
+	for _, item := range sortTree(list.Items) {
+		record := append(listColumns[:len(listColumns):len(listColumns)],
+			strconv.Itoa(item.Id),
+			intString(item.ParentId),
+			item.Title,
+			item.Description,
+			strconv.FormatBool(item.Done),
+			strconv.FormatBool(item.Archived),
+			item.CreatedAt.Format(time.RFC3339),
+			item.UpdatedAt.Format(time.RFC3339),
+			timeString(item.CompletedAt),
+			stringValue(item.DueDate),
+		)
+		if err := e.w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+
+	return e.w.Write(csvHeader)
+}
+
+// markdownEncoder writes a list as a heading followed by a checklist with subtasks indented
+// under their parents. Descriptions are written as continuation lines of their items.
+type markdownEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *markdownEncoder) Encode(list todo.ListExport) error {
+	var b strings.Builder
+	if e.count > 0 {
+		b.WriteString("\n")
+	}
+	e.count++
+
+	fmt.Fprintf(&b, "# %s\n", singleLine(list.Title))
+	if list.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", list.Description)
+	}
+
+	if len(list.Items) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, node := range sortTree(list.Items) {
+		indent := strings.Repeat("  ", node.depth)
+		check := " "
+		if node.Done {
+			check = "x"
+		}
+
+		fmt.Fprintf(&b, "%s- [%s] %s\n", indent, check, singleLine(node.Title))
+		if node.Description != "" {
+			for _, line := range strings.Split(node.Description, "\n") {
+				// a blank line would end the item
+				if strings.TrimSpace(line) != "" {
+					fmt.Fprintf(&b, "%s  %s\n", indent, line)
+				}
+			}
+		}
+	}
+
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *markdownEncoder) Close() error {
+	return nil
+}
+
+// todoTxtEncoder writes a line per item in the todo.txt format with the list's title
+// as the project of the task and the due date as due:YYYY-MM-DD. Subtasks are written
+// as tasks of their own.
+type todoTxtEncoder struct {
+	w io.Writer
+}
+
+func (e *todoTxtEncoder) Encode(list todo.ListExport) error {
+	var b strings.Builder
+	project := "+" + strings.Join(strings.Fields(list.Title), "-")
+
+	for _, item := range sortTree(list.Items) {
+		if item.Done {
+			b.WriteString("x ")
+			// the creation date may only be given along with the completion date
+			if item.CompletedAt != nil {
+				fmt.Fprintf(&b, "%s %s ", item.CompletedAt.Format(todo.DateLayout), item.CreatedAt.Format(todo.DateLayout))
+			}
+		} else {
+			fmt.Fprintf(&b, "%s ", item.CreatedAt.Format(todo.DateLayout))
+		}
+
+		fmt.Fprintf(&b, "%s %s", singleLine(item.Title), project)
+		if item.DueDate != nil {
+			fmt.Fprintf(&b, " %s%s", todoTxtDueKey, *item.DueDate)
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *todoTxtEncoder) Close() error {
+	return nil
+}
+
+// node is an item along with the number of its ancestors.
+type node struct {
+	todo.TodoItem
+	depth int
+}
+
+// sortTree orders the items depth first, each subtask right after its parent
+// and siblings by position. Items whose parent is missing are treated as roots.
+func sortTree(items []todo.TodoItem) []node {
+	ids := make(map[int]bool, len(items))
+	for _, item := range items {
+		ids[item.Id] = true
+	}
+
+	children := make(map[int][]todo.TodoItem)
+	var roots []todo.TodoItem
+	for _, item := range items {
+		if item.ParentId != nil && ids[*item.ParentId] {
+			children[*item.ParentId] = append(children[*item.ParentId], item)
+		} else {
+			roots = append(roots, item)
+		}
+	}
+
+	sorted := make([]node, 0, len(items))
+	var walk func(siblings []todo.TodoItem, depth int)
+	walk = func(siblings []todo.TodoItem, depth int) {
+		sort.SliceStable(siblings, func(i, j int) bool {
+			if siblings[i].Position != siblings[j].Position {
+				return siblings[i].Position < siblings[j].Position
+			}
+			return siblings[i].Id < siblings[j].Id
+		})
+
+		for _, item := range siblings {
+			sorted = append(sorted, node{TodoItem: item, depth: depth})
+			walk(children[item.Id], depth+1)
+		}
+	}
+	walk(roots, 0)
+
+	return sorted
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func intString(i *int) string {
+	if i == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*i)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func timeString(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,169 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"testing"
+	"time"
+)
+
+func intPointer(i int) *int {
+	return &i
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func testList() todo.ListExport {
+	createdAt := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2021, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	return todo.ListExport{
+		TodoList: todo.TodoList{Id: 1, Title: "Weekly Groceries", Description: "for the weekend", CreatedAt: createdAt, UpdatedAt: createdAt},
+		Items: []todo.TodoItem{
+			{Id: 3, Title: "Apples", ListId: 1, ParentId: intPointer(2), Position: "i", CreatedAt: createdAt, UpdatedAt: createdAt},
+			{Id: 4, Title: "Bread", Description: "wholegrain", ListId: 1, Position: "r", DueDate: stringPointer("2021-01-03"), CreatedAt: createdAt, UpdatedAt: createdAt},
+			{Id: 2, Title: "Fruit", Done: true, ListId: 1, Position: "i", CreatedAt: createdAt, UpdatedAt: completedAt, CompletedAt: &completedAt},
+		},
+		Tags: map[int][]string{4: {"bakery"}},
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		list    todo.ListExport
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Markdown",
+			format: todo.FormatMarkdown,
+			list:   testList(),
+			want: "# Weekly Groceries\n\nfor the weekend\n\n" +
+				"- [x] Fruit\n" +
+				"  - [ ] Apples\n" +
+				"- [ ] Bread\n" +
+				"  wholegrain\n",
+		},
+		{
+			name:   "Todo.txt",
+			format: todo.FormatTodoTxt,
+			list:   testList(),
+			want: "x 2021-01-02 2021-01-01 Fruit +Weekly-Groceries\n" +
+				"2021-01-01 Apples +Weekly-Groceries\n" +
+				"2021-01-01 Bread +Weekly-Groceries due:2021-01-03\n",
+		},
+		{
+			name:   "CSV",
+			format: todo.FormatCSV,
+			list:   testList(),
+			want: "list_id,list_title,list_description,list_archived,item_id,parent_id,title,description,done,archived,created_at,updated_at,completed_at,due_date\n" +
+				"1,Weekly Groceries,for the weekend,false,2,,Fruit,,true,false,2021-01-01T10:00:00Z,2021-01-02T10:00:00Z,2021-01-02T10:00:00Z,\n" +
+				"1,Weekly Groceries,for the weekend,false,3,2,Apples,,false,false,2021-01-01T10:00:00Z,2021-01-01T10:00:00Z,,\n" +
+				"1,Weekly Groceries,for the weekend,false,4,,Bread,wholegrain,false,false,2021-01-01T10:00:00Z,2021-01-01T10:00:00Z,,2021-01-03\n",
+		},
+		{
+			name:   "CSV Empty List",
+			format: todo.FormatCSV,
+			list:   todo.ListExport{TodoList: todo.TodoList{Id: 1, Title: "Empty"}},
+			want: "list_id,list_title,list_description,list_archived,item_id,parent_id,title,description,done,archived,created_at,updated_at,completed_at,due_date\n" +
+				"1,Empty,,false,,,,,,,,,,\n",
+		},
+		{
+			name:   "JSON",
+			format: todo.FormatJSON,
+			list:   todo.ListExport{TodoList: todo.TodoList{Id: 1, Title: "Empty"}},
+			want: `{"id":1,"title":"Empty","description":"","position":"","is_template":false,"archived":false,` +
+				`"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","created_by":null,"updated_by":null,` +
+				`"version":0,"items":null}` + "\n",
+		},
+		{
+			name:    "Unknown Format",
+			format:  "xml",
+			list:    testList(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+
+			err := Encode(&b, tt.format, tt.list)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, b.String())
+			}
+		})
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	lists := []todo.ListExport{
+		{TodoList: todo.TodoList{Id: 1, Title: "First"}},
+		{TodoList: todo.TodoList{Id: 2, Title: "Second"}, Items: []todo.TodoItem{{Id: 3, Title: "Item", Done: true}}},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		lists  []todo.ListExport
+		want   string
+	}{
+		{
+			name:   "Markdown",
+			format: todo.FormatMarkdown,
+			lists:  lists,
+			want:   "# First\n\n# Second\n\n- [x] Item\n",
+		},
+		{
+			name:   "JSON No Lists",
+			format: todo.FormatJSON,
+			want:   "[]\n",
+		},
+		{
+			name:   "CSV No Lists",
+			format: todo.FormatCSV,
+			want:   "list_id,list_title,list_description,list_archived,item_id,parent_id,title,description,done,archived,created_at,updated_at,completed_at,due_date\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+
+			encoder, err := NewEncoder(&b, tt.format)
+			assert.NoError(t, err)
+
+			for _, list := range tt.lists {
+				assert.NoError(t, encoder.Encode(list))
+			}
+			assert.NoError(t, encoder.Close())
+
+			assert.Equal(t, tt.want, b.String())
+		})
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	var b bytes.Buffer
+
+	encoder, err := NewEncoder(&b, todo.FormatJSON)
+	assert.NoError(t, err)
+
+	assert.NoError(t, encoder.Encode(todo.ListExport{TodoList: todo.TodoList{Id: 1}}))
+	assert.NoError(t, encoder.Encode(todo.ListExport{TodoList: todo.TodoList{Id: 2}}))
+	assert.NoError(t, encoder.Close())
+
+	var lists []todo.ListExport
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &lists))
+	assert.Len(t, lists, 2)
+	assert.Equal(t, 2, lists[1].Id)
+}
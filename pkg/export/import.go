@@ -0,0 +1,691 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zhashkevych/todo-app"
+)
+
+const (
+	// DefaultListTitle is the title of the list items are imported into when the file doesn't name one.
+	DefaultListTitle = "Imported"
+
+	maxTextLength = 255
+)
+
+var (
+	markdownHeading = regexp.MustCompile(`^#{1,6}\s+(.*)$`)
+	markdownItem    = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(?:\[([ xX])\]\s*)?(.*)$`)
+	todoTxtDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	todoTxtPriority = regexp.MustCompile(`^\([A-Z]\)$`)
+)
+
+// csvColumns maps the accepted column names, including those used by other apps, to the exported ones.
+var csvColumns = map[string]string{
+	"list_id":          "list_id",
+	"list_title":       "list_title",
+	"list":             "list_title",
+	"project":          "list_title",
+	"list_description": "list_description",
+	"list_archived":    "list_archived",
+	"item_id":          "item_id",
+	"id":               "item_id",
+	"parent_id":        "parent_id",
+	"title":            "title",
+	"task":             "title",
+	"content":          "title",
+	"name":             "title",
+	"description":      "description",
+	"notes":            "description",
+	"done":             "done",
+	"completed":        "done",
+	"archived":         "archived",
+	"completed_at":     "completed_at",
+	"due_date":         "due_date",
+	"due":              "due_date",
+}
+
+// csvItemColumns are empty in rows of lists without items.
+var csvItemColumns = []string{"item_id", "parent_id", "title", "description", "done", "archived", "completed_at", "due_date"}
+
+// Decode reads the lists of a file in the given format. Items reference their parents by ids
+// that are only meaningful within the result and are ordered so that parents come first.
+// Rows that can't be imported are reported as import errors rather than failing the whole file,
+// an error is returned only if the file can't be read at all. Items whose parents form a cycle
+// are reported and kept as top-level items.
+func Decode(r io.Reader, format string) ([]todo.ListExport, []todo.ImportError, error) {
+	d := &decoder{listKeys: make(map[string]int), itemIndexes: make(map[int]int), itemOrigins: make(map[int]itemOrigin)}
+
+	var err error
+	switch format {
+	case todo.FormatJSON:
+		err = d.decodeJSON(r)
+	case todo.FormatCSV:
+		err = d.decodeCSV(r)
+	case todo.FormatMarkdown:
+		err = d.decodeMarkdown(r)
+	case todo.FormatTodoTxt:
+		err = d.decodeTodoTxt(r)
+	default:
+		err = fmt.Errorf("unknown import format %q", format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range d.lists {
+		d.breakCycles(i)
+
+		nodes := sortTree(d.lists[i].Items)
+		items := make([]todo.TodoItem, len(nodes))
+		for j, node := range nodes {
+			items[j] = node.TodoItem
+		}
+		d.lists[i].Items = items
+	}
+
+	return d.lists, d.errors, nil
+}
+
+// Format infers the format of a file from its name.
+func Format(filename string) (string, error) {
+	i := strings.LastIndex(filename, ".")
+	if i < 0 {
+		return "", fmt.Errorf("can't infer the format of %q", filename)
+	}
+
+	switch strings.ToLower(filename[i+1:]) {
+	case "json":
+		return todo.FormatJSON, nil
+	case "csv":
+		return todo.FormatCSV, nil
+	case "md", "markdown":
+		return todo.FormatMarkdown, nil
+	case "txt":
+		return todo.FormatTodoTxt, nil
+	default:
+		return "", fmt.Errorf("can't infer the format of %q", filename)
+	}
+}
+
+// itemOrigin locates an item in the file for reporting it. Prefix precedes the messages
+// of formats without rows.
+type itemOrigin struct {
+	row    int
+	prefix string
+}
+
+type decoder struct {
+	lists    []todo.ListExport
+	listKeys map[string]int
+	// itemIndexes maps the assigned item ids, which are unique across lists, to the indexes of the items in their list
+	itemIndexes map[int]int
+	itemOrigins map[int]itemOrigin
+	errors      []todo.ImportError
+	lastId      int
+}
+
+func (d *decoder) fail(row int, format string, args ...interface{}) {
+	d.errors = append(d.errors, todo.ImportError{Row: row, Message: fmt.Sprintf(format, args...)})
+}
+
+// list returns the index of the list with the key, adding the list if there is none.
+// An empty key always adds a new list.
+func (d *decoder) list(key string, list todo.TodoList, row int) int {
+	if i, ok := d.listKeys[key]; ok && key != "" {
+		return i
+	}
+
+	if msg := checkText("list title", list.Title, true); msg != "" {
+		d.fail(row, msg)
+	}
+	if msg := checkText("list description", list.Description, false); msg != "" {
+		d.fail(row, msg)
+	}
+
+	d.lists = append(d.lists, todo.ListExport{TodoList: list})
+	i := len(d.lists) - 1
+	if key != "" {
+		d.listKeys[key] = i
+	}
+
+	return i
+}
+
+// addItem adds the item to the list and returns the id assigned to it.
+func (d *decoder) addItem(list int, item todo.TodoItem, row int) int {
+	if msg := checkText("title", item.Title, true); msg != "" {
+		d.fail(row, msg)
+	}
+	if msg := checkText("description", item.Description, false); msg != "" {
+		d.fail(row, msg)
+	}
+
+	d.lastId++
+	item.Id = d.lastId
+	d.itemIndexes[item.Id] = len(d.lists[list].Items)
+	d.itemOrigins[item.Id] = itemOrigin{row: row}
+	d.lists[list].Items = append(d.lists[list].Items, item)
+
+	return item.Id
+}
+
+// dueDate returns the due date if it's valid and reports it otherwise.
+func (d *decoder) dueDate(date *string, row int) *string {
+	if date == nil {
+		return nil
+	}
+
+	if _, err := time.Parse(todo.DateLayout, *date); err != nil {
+		d.fail(row, "invalid due date %q", *date)
+		return nil
+	}
+
+	return date
+}
+
+// setTags tags the item with the names that are valid and reports the others.
+func (d *decoder) setTags(list, itemId int, names []string, row int) {
+	for _, name := range names {
+		if msg := checkText("tag", name, true); msg != "" {
+			d.fail(row, msg)
+			continue
+		}
+
+		if d.lists[list].Tags == nil {
+			d.lists[list].Tags = make(map[int][]string)
+		}
+		d.lists[list].Tags[itemId] = append(d.lists[list].Tags[itemId], name)
+	}
+}
+
+func (d *decoder) setParent(list, itemId, parentId int) {
+	if i, ok := d.itemIndexes[itemId]; ok {
+		d.lists[list].Items[i].ParentId = &parentId
+	}
+}
+
+// breakCycles reports the items of the list whose parents lead back to them and makes them top-level
+// items, otherwise they couldn't be ordered after their parents and would be lost.
+func (d *decoder) breakCycles(list int) {
+	items := d.lists[list].Items
+	parents := make(map[int]int, len(items))
+	for _, item := range items {
+		if item.ParentId != nil {
+			parents[item.Id] = *item.ParentId
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[int]int, len(items))
+	inCycle := make(map[int]bool)
+	for _, item := range items {
+		var path []int
+		id, ok := item.Id, true
+		for ok && state[id] == 0 {
+			state[id] = visiting
+			path = append(path, id)
+			id, ok = parents[id]
+		}
+
+		if ok && state[id] == visiting {
+			// the path has led back to one of its items, which starts the cycle
+			for i := len(path) - 1; i >= 0; i-- {
+				inCycle[path[i]] = true
+				if path[i] == id {
+					break
+				}
+			}
+		}
+
+		for _, pathId := range path {
+			state[pathId] = visited
+		}
+	}
+
+	for i := range items {
+		if inCycle[items[i].Id] {
+			origin := d.itemOrigins[items[i].Id]
+			d.fail(origin.row, "%sparent forms a cycle", origin.prefix)
+			items[i].ParentId = nil
+		}
+	}
+}
+
+func checkText(field, value string, required bool) string {
+	if required && strings.TrimSpace(value) == "" {
+		return field + " is required"
+	}
+
+	if utf8.RuneCountInString(value) > maxTextLength {
+		return fmt.Sprintf("%s is longer than %d characters", field, maxTextLength)
+	}
+
+	return ""
+}
+
+// decodeJSON reads a single list or an array of lists as written by the JSON export.
+func (d *decoder) decodeJSON(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var lists []todo.ListExport
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var list todo.ListExport
+		err = json.Unmarshal(trimmed, &list)
+		lists = []todo.ListExport{list}
+	} else {
+		err = json.Unmarshal(data, &lists)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid json: %s", err.Error())
+	}
+
+	for n, list := range lists {
+		before := len(d.errors)
+		i := d.list("", todo.TodoList{
+			Title:       list.Title,
+			Description: list.Description,
+			IsTemplate:  list.IsTemplate,
+			Archived:    list.Archived,
+		}, 0)
+		prefixErrors(d.errors[before:], fmt.Sprintf("list %d: ", n+1))
+
+		ids := make(map[int]int, len(list.Items))
+		for _, item := range list.Items {
+			before := len(d.errors)
+			if _, ok := ids[item.Id]; ok {
+				d.fail(0, "duplicate id")
+			}
+
+			id := d.addItem(i, todo.TodoItem{
+				Title:       item.Title,
+				Description: item.Description,
+				Done:        item.Done,
+				Archived:    item.Archived,
+				CompletedAt: item.CompletedAt,
+				DueDate:     d.dueDate(item.DueDate, 0),
+				// keeps the order of the items until positions are assigned on creation
+				Position: item.Position,
+			}, 0)
+			ids[item.Id] = id
+			d.itemOrigins[id] = itemOrigin{prefix: fmt.Sprintf("list %d, item %d: ", n+1, item.Id)}
+			prefixErrors(d.errors[before:], d.itemOrigins[id].prefix)
+		}
+
+		for _, item := range list.Items {
+			if item.ParentId == nil {
+				continue
+			}
+
+			parentId, ok := ids[*item.ParentId]
+			if !ok {
+				d.fail(0, "list %d, item %d: parent %d does not exist", n+1, item.Id, *item.ParentId)
+				continue
+			}
+			d.setParent(i, ids[item.Id], parentId)
+		}
+
+		taggedIds := make([]int, 0, len(list.Tags))
+		for itemId := range list.Tags {
+			taggedIds = append(taggedIds, itemId)
+		}
+		sort.Ints(taggedIds)
+
+		for _, itemId := range taggedIds {
+			id, ok := ids[itemId]
+			if !ok {
+				d.fail(0, "list %d: tagged item %d does not exist", n+1, itemId)
+				continue
+			}
+
+			before := len(d.errors)
+			d.setTags(i, id, list.Tags[itemId], 0)
+			prefixErrors(d.errors[before:], fmt.Sprintf("list %d, item %d: ", n+1, itemId))
+		}
+	}
+
+	return nil
+}
+
+func prefixErrors(errors []todo.ImportError, prefix string) {
+	for i := range errors {
+		errors[i].Message = prefix + errors[i].Message
+	}
+}
+
+// decodeCSV reads a header followed by a row per item, as written by the CSV export. Only a title
+// column is required and common column names of other apps are accepted. Rows of the same
+// list_id, or list title if there are no ids, belong to the same list.
+func (d *decoder) decodeCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("csv has no header")
+		}
+		return err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		if column, ok := csvColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			if _, ok := columns[column]; !ok {
+				columns[column] = i
+			}
+		}
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return errors.New("csv has no title column")
+	}
+
+	type itemRef struct {
+		list int
+		key  string
+	}
+	type parentRef struct {
+		itemRef
+		itemId int
+		row    int
+	}
+
+	itemIds := make(map[itemRef]int)
+	var parents []parentRef
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				d.fail(row, parseErr.Err.Error())
+				continue
+			}
+			return err
+		}
+
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		listTitle, listKey := field("list_title"), field("list_id")
+		if listTitle == "" && listKey == "" {
+			listTitle = DefaultListTitle
+		}
+		if listKey == "" {
+			listKey = "title:" + listTitle
+		}
+
+		listArchived, err := parseBool(field("list_archived"))
+		if err != nil {
+			d.fail(row, "invalid list_archived: %s", err.Error())
+		}
+
+		list := d.list(listKey, todo.TodoList{
+			Title:       listTitle,
+			Description: field("list_description"),
+			Archived:    listArchived,
+		}, row)
+
+		if allEmpty(field, csvItemColumns) {
+			// a row of a list without items
+			continue
+		}
+
+		done, err := parseBool(field("done"))
+		if err != nil {
+			d.fail(row, "invalid done: %s", err.Error())
+		}
+
+		archived, err := parseBool(field("archived"))
+		if err != nil {
+			d.fail(row, "invalid archived: %s", err.Error())
+		}
+
+		completedAt, err := parseTime(field("completed_at"))
+		if err != nil {
+			d.fail(row, "invalid completed_at: %s", err.Error())
+		}
+
+		var dueDate *string
+		if due := field("due_date"); due != "" {
+			dueDate = d.dueDate(&due, row)
+		}
+
+		id := d.addItem(list, todo.TodoItem{
+			Title:       field("title"),
+			Description: field("description"),
+			Done:        done || completedAt != nil,
+			Archived:    archived,
+			CompletedAt: completedAt,
+			DueDate:     dueDate,
+		}, row)
+
+		if key := field("item_id"); key != "" {
+			ref := itemRef{list: list, key: key}
+			if _, ok := itemIds[ref]; ok {
+				d.fail(row, "duplicate item_id %s", key)
+			}
+			itemIds[ref] = id
+		}
+
+		if parent := field("parent_id"); parent != "" {
+			parents = append(parents, parentRef{itemRef: itemRef{list: list, key: parent}, itemId: id, row: row})
+		}
+	}
+
+	// parents are resolved at the end since they may follow their subtasks
+	for _, ref := range parents {
+		parentId, ok := itemIds[ref.itemRef]
+		if !ok {
+			d.fail(ref.row, "parent %s does not exist", ref.key)
+			continue
+		}
+		d.setParent(ref.list, ref.itemId, parentId)
+	}
+
+	return nil
+}
+
+func allEmpty(field func(column string) string, columns []string) bool {
+	for _, column := range columns {
+		if field(column) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "", "false", "0", "no":
+		return false, nil
+	case "true", "1", "yes", "x":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%q is not a boolean", s)
+	}
+}
+
+func parseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, todo.DateLayout} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%q is not a date", s)
+}
+
+// decodeMarkdown reads headings as lists and list items, checklists or not, as items,
+// with subtasks indented under their parents. Indented lines following an item are its
+// description and lines between a heading and its first item are the list's description.
+func (d *decoder) decodeMarkdown(r io.Reader) error {
+	type level struct {
+		indent int
+		itemId int
+	}
+
+	// list and last are the indexes of the current list and of its last item
+	list, last := -1, -1
+	var stack []level
+	lastIndent := 0
+
+	scanner := bufio.NewScanner(r)
+	for row := 1; scanner.Scan(); row++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if match := markdownHeading.FindStringSubmatch(line); match != nil {
+			list = d.list("", todo.TodoList{Title: strings.TrimSpace(match[1])}, row)
+			stack, last = nil, -1
+			continue
+		}
+
+		if match := markdownItem.FindStringSubmatch(line); match != nil {
+			if list < 0 {
+				list = d.list("", todo.TodoList{Title: DefaultListTitle}, row)
+			}
+
+			indent := indentWidth(match[1])
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+
+			id := d.addItem(list, todo.TodoItem{
+				Title: strings.TrimSpace(match[3]),
+				Done:  strings.EqualFold(match[2], "x"),
+			}, row)
+			if len(stack) > 0 {
+				d.setParent(list, id, stack[len(stack)-1].itemId)
+			}
+
+			stack = append(stack, level{indent: indent, itemId: id})
+			last, lastIndent = len(d.lists[list].Items)-1, indent
+			continue
+		}
+
+		text := strings.TrimSpace(line)
+		switch {
+		case last >= 0 && indentWidth(line[:len(line)-len(strings.TrimLeft(line, " \t"))]) > lastIndent:
+			item := &d.lists[list].Items[last]
+			item.Description = joinLines(item.Description, text)
+			if msg := checkText("description", item.Description, false); msg != "" {
+				d.fail(row, msg)
+			}
+		case list >= 0 && len(d.lists[list].Items) == 0:
+			d.lists[list].Description = joinLines(d.lists[list].Description, text)
+			if msg := checkText("list description", d.lists[list].Description, false); msg != "" {
+				d.fail(row, msg)
+			}
+		default:
+			d.fail(row, "line is neither a heading nor a list item")
+		}
+	}
+
+	return scanner.Err()
+}
+
+func indentWidth(indent string) int {
+	return len(strings.ReplaceAll(indent, "\t", "    "))
+}
+
+func joinLines(text, line string) string {
+	if text == "" {
+		return line
+	}
+
+	return text + "\n" + line
+}
+
+// decodeTodoTxt reads a task per line in the todo.txt format. A task goes to the list named
+// by its first +project, which is removed from the title, and to the default list if it has none.
+// The due:YYYY-MM-DD extension sets the due date.
+func (d *decoder) decodeTodoTxt(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for row := 1; scanner.Scan(); row++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		var item todo.TodoItem
+		if fields[0] == "x" {
+			item.Done = true
+			fields = fields[1:]
+
+			if len(fields) > 0 && todoTxtDate.MatchString(fields[0]) {
+				completedAt, err := time.Parse(todo.DateLayout, fields[0])
+				if err != nil {
+					d.fail(row, "invalid completion date: %s", fields[0])
+				} else {
+					item.CompletedAt = &completedAt
+				}
+				fields = fields[1:]
+			}
+		} else if len(fields) > 0 && todoTxtPriority.MatchString(fields[0]) {
+			fields = fields[1:]
+		}
+
+		// the creation date isn't kept
+		if len(fields) > 0 && todoTxtDate.MatchString(fields[0]) {
+			fields = fields[1:]
+		}
+
+		listTitle := DefaultListTitle
+		title := make([]string, 0, len(fields))
+		projectFound := false
+		for _, field := range fields {
+			if !projectFound && len(field) > 1 && field[0] == '+' {
+				listTitle, projectFound = field[1:], true
+				continue
+			}
+
+			if strings.HasPrefix(field, todoTxtDueKey) && item.DueDate == nil {
+				date := strings.TrimPrefix(field, todoTxtDueKey)
+				item.DueDate = d.dueDate(&date, row)
+				continue
+			}
+
+			title = append(title, field)
+		}
+		item.Title = strings.Join(title, " ")
+
+		list := d.list("project:"+listTitle, todo.TodoList{Title: listTitle}, row)
+		d.addItem(list, item, row)
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,306 @@
+package export
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	completedAt := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		format     string
+		input      string
+		want       []todo.ListExport
+		wantErrors []todo.ImportError
+		wantErr    bool
+	}{
+		{
+			name:   "Markdown",
+			format: todo.FormatMarkdown,
+			input: "# Groceries\n\nfor the weekend\n\n" +
+				"- [x] Fruit\n" +
+				"  - [ ] Apples\n" +
+				"    green ones\n" +
+				"- Bread\n" +
+				"\n# Work\n" +
+				"* [X] Report\n",
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: "Groceries", Description: "for the weekend"},
+					Items: []todo.TodoItem{
+						{Id: 1, Title: "Fruit", Done: true},
+						{Id: 2, Title: "Apples", Description: "green ones", ParentId: intPointer(1)},
+						{Id: 3, Title: "Bread"},
+					},
+				},
+				{
+					TodoList: todo.TodoList{Title: "Work"},
+					Items: []todo.TodoItem{
+						{Id: 4, Title: "Report", Done: true},
+					},
+				},
+			},
+		},
+		{
+			name:   "Markdown Without Heading",
+			format: todo.FormatMarkdown,
+			input:  "- [ ] Call mom\nsome notes\n- [ ]\n",
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: DefaultListTitle},
+					Items: []todo.TodoItem{
+						{Id: 1, Title: "Call mom"},
+						{Id: 2, Title: ""},
+					},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Row: 2, Message: "line is neither a heading nor a list item"},
+				{Row: 3, Message: "title is required"},
+			},
+		},
+		{
+			name:   "Todo.txt",
+			format: todo.FormatTodoTxt,
+			input: "x 2021-01-02 2021-01-01 Fruit +Weekly-Groceries\n" +
+				"(A) 2021-01-01 Call mom @phone\n" +
+				"2021-01-01 Bread +Weekly-Groceries +Bakery due:2021-01-03\n" +
+				"Pay rent due:tomorrow\n",
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: "Weekly-Groceries"},
+					Items: []todo.TodoItem{
+						{Id: 1, Title: "Fruit", Done: true, CompletedAt: &completedAt},
+						{Id: 3, Title: "Bread +Bakery", DueDate: stringPointer("2021-01-03")},
+					},
+				},
+				{
+					TodoList: todo.TodoList{Title: DefaultListTitle},
+					Items: []todo.TodoItem{
+						{Id: 2, Title: "Call mom @phone"},
+						{Id: 4, Title: "Pay rent"},
+					},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Row: 4, Message: `invalid due date "tomorrow"`},
+			},
+		},
+		{
+			name:   "CSV",
+			format: todo.FormatCSV,
+			input: "list_id,list_title,item_id,parent_id,title,done,completed_at,due_date\n" +
+				"1,Groceries,3,2,Apples,false,,2021-01-31\n" +
+				"1,Groceries,2,,Fruit,true,2021-01-02T00:00:00Z,someday\n" +
+				"5,Empty,,,,,,\n",
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: "Groceries"},
+					Items: []todo.TodoItem{
+						{Id: 2, Title: "Fruit", Done: true, CompletedAt: &completedAt},
+						{Id: 1, Title: "Apples", ParentId: intPointer(2), DueDate: stringPointer("2021-01-31")},
+					},
+				},
+				{
+					TodoList: todo.TodoList{Title: "Empty"},
+					Items:    []todo.TodoItem{},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Row: 3, Message: `invalid due date "someday"`},
+			},
+		},
+		{
+			name:   "CSV Of Another App",
+			format: todo.FormatCSV,
+			input: "Content,Project,Completed\n" +
+				"Buy milk,Home,maybe\n" +
+				"Pay rent,,\n" +
+				"Water plants,Home,yes,extra\n" +
+				"Fix bike,Home,no,,3\n",
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: "Home"},
+					Items: []todo.TodoItem{
+						{Id: 1, Title: "Buy milk"},
+						{Id: 3, Title: "Water plants", Done: true},
+						{Id: 4, Title: "Fix bike"},
+					},
+				},
+				{
+					TodoList: todo.TodoList{Title: DefaultListTitle},
+					Items: []todo.TodoItem{
+						{Id: 2, Title: "Pay rent"},
+					},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Row: 2, Message: `invalid done: "maybe" is not a boolean`},
+			},
+		},
+		{
+			name:   "CSV Unknown Parent",
+			format: todo.FormatCSV,
+			input:  "title,item_id,parent_id\nApples,1,7\n",
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: DefaultListTitle},
+					Items: []todo.TodoItem{
+						{Id: 1, Title: "Apples"},
+					},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Row: 2, Message: "parent 7 does not exist"},
+			},
+		},
+		{
+			name:   "CSV Parent Cycle",
+			format: todo.FormatCSV,
+			input:  "title,item_id,parent_id\nFirst,a,b\nSecond,b,a\nThird,c,\n",
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: DefaultListTitle},
+					Items: []todo.TodoItem{
+						{Id: 1, Title: "First"},
+						{Id: 2, Title: "Second"},
+						{Id: 3, Title: "Third"},
+					},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Row: 2, Message: "parent forms a cycle"},
+				{Row: 3, Message: "parent forms a cycle"},
+			},
+		},
+		{
+			name:    "CSV Without Title",
+			format:  todo.FormatCSV,
+			input:   "list,done\nHome,true\n",
+			wantErr: true,
+		},
+		{
+			name:   "JSON",
+			format: todo.FormatJSON,
+			input: `[{"title":"Groceries","archived":true,"items":[` +
+				`{"id":11,"title":"Apples","parent_id":10},{"id":10,"title":"Fruit","done":true}],` +
+				`"tags":{"11":["home",""],"12":["work"]}},` +
+				`{"title":"","items":[{"id":1,"title":"Orphan","parent_id":5}]}]`,
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: "Groceries", Archived: true},
+					Items: []todo.TodoItem{
+						{Id: 2, Title: "Fruit", Done: true},
+						{Id: 1, Title: "Apples", ParentId: intPointer(2)},
+					},
+					Tags: map[int][]string{1: {"home"}},
+				},
+				{
+					TodoList: todo.TodoList{Title: ""},
+					Items: []todo.TodoItem{
+						{Id: 3, Title: "Orphan"},
+					},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Message: "list 1, item 11: tag is required"},
+				{Message: "list 1: tagged item 12 does not exist"},
+				{Message: "list 2: list title is required"},
+				{Message: "list 2, item 1: parent 5 does not exist"},
+			},
+		},
+		{
+			name:   "JSON Single List",
+			format: todo.FormatJSON,
+			input:  `{"title":"Groceries","description":"` + strings.Repeat("a", 256) + `"}`,
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: "Groceries", Description: strings.Repeat("a", 256)},
+					Items:    []todo.TodoItem{},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Message: "list 1: list description is longer than 255 characters"},
+			},
+		},
+		{
+			name:   "JSON Parent Cycle",
+			format: todo.FormatJSON,
+			input: `{"title":"Groceries","items":[{"id":1,"title":"Fruit","parent_id":2},{"id":2,"title":"Apples","parent_id":1},` +
+				`{"id":3,"title":"Bread","parent_id":3},{"id":4,"title":"Milk"},{"id":5,"title":"Pears","parent_id":1}]}`,
+			want: []todo.ListExport{
+				{
+					TodoList: todo.TodoList{Title: "Groceries"},
+					Items: []todo.TodoItem{
+						{Id: 1, Title: "Fruit"},
+						{Id: 5, Title: "Pears", ParentId: intPointer(1)},
+						{Id: 2, Title: "Apples"},
+						{Id: 3, Title: "Bread"},
+						{Id: 4, Title: "Milk"},
+					},
+				},
+			},
+			wantErrors: []todo.ImportError{
+				{Message: "list 1, item 1: parent forms a cycle"},
+				{Message: "list 1, item 2: parent forms a cycle"},
+				{Message: "list 1, item 3: parent forms a cycle"},
+			},
+		},
+		{
+			name:    "Invalid JSON",
+			format:  todo.FormatJSON,
+			input:   `[{"title":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErrors, err := Decode(strings.NewReader(tt.input), tt.format)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantErrors, gotErrors)
+			}
+		})
+	}
+}
+
+func TestDecode_RoundTrip(t *testing.T) {
+	for _, format := range []string{todo.FormatJSON, todo.FormatCSV, todo.FormatMarkdown} {
+		t.Run(format, func(t *testing.T) {
+			var b bytes.Buffer
+			assert.NoError(t, Encode(&b, format, testList()))
+
+			lists, importErrors, err := Decode(&b, format)
+			assert.NoError(t, err)
+			assert.Empty(t, importErrors)
+
+			assert.Len(t, lists, 1)
+			assert.Equal(t, "Weekly Groceries", lists[0].Title)
+
+			var titles []string
+			for _, item := range lists[0].Items {
+				titles = append(titles, item.Title)
+			}
+			assert.Equal(t, []string{"Fruit", "Apples", "Bread"}, titles)
+			assert.True(t, lists[0].Items[0].Done)
+			assert.Equal(t, lists[0].Items[0].Id, *lists[0].Items[1].ParentId)
+			assert.Equal(t, "wholegrain", lists[0].Items[2].Description)
+			if format != todo.FormatMarkdown {
+				assert.Equal(t, stringPointer("2021-01-03"), lists[0].Items[2].DueDate)
+			}
+			if format == todo.FormatJSON {
+				assert.Equal(t, map[int][]string{lists[0].Items[2].Id: {"bakery"}}, lists[0].Tags)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(lists *service_mocks.MockTodoList, items *service_mocks.MockTodoItem)
+
+	done := true
+	version := 2
+
+	tests := []struct {
+		name           string
+		query          string
+		variables      map[string]interface{}
+		mockBehavior   mockBehavior
+		expectedData   string
+		expectedErrors []string
+	}{
+		{
+			name:  "Lists With Items",
+			query: `{ lists { id title items { id title parentId } } }`,
+			mockBehavior: func(lists *service_mocks.MockTodoList, items *service_mocks.MockTodoItem) {
+				lists.EXPECT().GetAll(1, todo.ListFilter{}).Return([]todo.TodoList{
+					{Id: 1, Title: "Groceries"},
+					{Id: 2, Title: "Work"},
+					{Id: 3, Title: "Empty"},
+				}, 3, nil)
+				// the items of all lists are loaded at once
+				items.EXPECT().GetAllByLists(1, gomock.Any()).DoAndReturn(func(userId int, listIds []int) ([]todo.TodoItem, error) {
+					assert.ElementsMatch(t, []int{1, 2, 3}, listIds)
+					return []todo.TodoItem{
+						{Id: 1, ListId: 1, Title: "Bread"},
+						{Id: 2, ListId: 1, Title: "Rye", ParentId: intPointer(1)},
+						{Id: 3, ListId: 2, Title: "Report"},
+						{Id: 4, ListId: 2, Title: "Old report", Archived: true},
+					}, nil
+				})
+			},
+			expectedData: `{"lists":[` +
+				`{"id":"1","title":"Groceries","items":[{"id":"1","title":"Bread","parentId":null},{"id":"2","title":"Rye","parentId":"1"}]},` +
+				`{"id":"2","title":"Work","items":[{"id":"3","title":"Report","parentId":null}]},` +
+				`{"id":"3","title":"Empty","items":[]}]}`,
+		},
+		{
+			name:  "Update Item",
+			query: `mutation ($id: ID!, $version: Int) { updateItem(id: $id, input: {done: true}, version: $version) { id done version } }`,
+			variables: map[string]interface{}{
+				"id":      "1",
+				"version": 2,
+			},
+			mockBehavior: func(lists *service_mocks.MockTodoList, items *service_mocks.MockTodoItem) {
+				items.EXPECT().Update(1, 1, todo.UpdateItemInput{Done: &done}, &version).Return(nil)
+				items.EXPECT().GetById(1, 1).Return(todo.TodoItem{Id: 1, Done: true, Version: 3}, nil)
+			},
+			expectedData: `{"updateItem":{"id":"1","done":true,"version":3}}`,
+		},
+		{
+			name:  "Service Error",
+			query: `{ list(id: "1") { title } }`,
+			mockBehavior: func(lists *service_mocks.MockTodoList, items *service_mocks.MockTodoItem) {
+				lists.EXPECT().GetById(1, 1).Return(todo.TodoList{}, errors.New("something went wrong"))
+			},
+			expectedData:   `null`,
+			expectedErrors: []string{"something went wrong"},
+		},
+		{
+			name:           "Invalid Id",
+			query:          `{ item(id: "one") { title } }`,
+			mockBehavior:   func(lists *service_mocks.MockTodoList, items *service_mocks.MockTodoItem) {},
+			expectedData:   `null`,
+			expectedErrors: []string{"invalid id"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			lists := service_mocks.NewMockTodoList(c)
+			items := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(lists, items)
+
+			services := &service.Service{TodoList: lists, TodoItem: items}
+
+			// Execute Query
+			response := Execute(context.Background(), services, 1, test.query, "", test.variables)
+
+			// Assert
+			var errs []string
+			for _, err := range response.Errors {
+				errs = append(errs, err.Message)
+			}
+			assert.Equal(t, test.expectedErrors, errs)
+			assert.JSONEq(t, test.expectedData, string(response.Data))
+		})
+	}
+}
+
+func TestItemLoader(t *testing.T) {
+	var calls [][]int
+	loader := newItemLoader(func(listIds []int) ([]todo.TodoItem, error) {
+		calls = append(calls, listIds)
+		return []todo.TodoItem{{Id: 1, ListId: 2}}, nil
+	})
+
+	loader.prime(1, 2, 2)
+
+	items, err := loader.load(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []todo.TodoItem{{Id: 1, ListId: 2}}, items)
+
+	items, err = loader.load(1)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	// lists that weren't primed are loaded on their own
+	_, err = loader.load(3)
+	assert.NoError(t, err)
+
+	assert.Equal(t, [][]int{{2, 1}, {3}}, calls)
+}
+
+func intPointer(i int) *int {
+	return &i
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+)
+
+var errInvalidId = errors.New("invalid id")
+
+type requestKey struct{}
+
+// request holds the state of a single query.
+type request struct {
+	services *service.Service
+	userId   int
+	items    *itemLoader
+}
+
+func newRequest(services *service.Service, userId int) *request {
+	return &request{
+		services: services,
+		userId:   userId,
+		items: newItemLoader(func(listIds []int) ([]todo.TodoItem, error) {
+			return services.TodoItem.GetAllByLists(userId, listIds)
+		}),
+	}
+}
+
+func fromContext(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// itemLoader batches loading the items of lists to avoid a query per list. The lists
+// resolved by a query are registered with the loader, and the first of them whose items
+// are requested loads the items of all of them at once.
+type itemLoader struct {
+	fetch func(listIds []int) ([]todo.TodoItem, error)
+
+	mu      sync.Mutex
+	pending []int
+	items   map[int][]todo.TodoItem
+}
+
+func newItemLoader(fetch func(listIds []int) ([]todo.TodoItem, error)) *itemLoader {
+	return &itemLoader{fetch: fetch, items: make(map[int][]todo.TodoItem)}
+}
+
+// prime registers the lists whose items are likely to be requested.
+func (l *itemLoader) prime(listIds ...int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pending = append(l.pending, listIds...)
+}
+
+// load returns the items of the list, loading them together with the items of the pending lists.
+func (l *itemLoader) load(listId int) ([]todo.TodoItem, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if items, ok := l.items[listId]; ok {
+		return items, nil
+	}
+
+	listIds := []int{listId}
+	seen := map[int]bool{listId: true}
+	for _, id := range l.pending {
+		if _, ok := l.items[id]; !ok && !seen[id] {
+			listIds = append(listIds, id)
+			seen[id] = true
+		}
+	}
+	l.pending = nil
+
+	items, err := l.fetch(listIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range listIds {
+		l.items[id] = nil
+	}
+	for _, item := range items {
+		l.items[item.ListId] = append(l.items[item.ListId], item)
+	}
+
+	return l.items[listId], nil
+}
+
+func parseId(id graphql.ID) (int, error) {
+	value, err := strconv.Atoi(string(id))
+	if err != nil || value <= 0 {
+		return 0, errInvalidId
+	}
+
+	return value, nil
+}
+
+func formatId(id int) graphql.ID {
+	return graphql.ID(strconv.Itoa(id))
+}
+
+func intValue(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+
+	i := int(*value)
+	return &i
+}
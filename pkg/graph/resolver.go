@@ -0,0 +1,247 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/zhashkevych/todo-app"
+)
+
+// Resolver resolves the queries and mutations.
+type Resolver struct{}
+
+type archivedArgs struct {
+	IncludeArchived bool
+}
+
+type idArgs struct {
+	Id graphql.ID
+}
+
+func (r *Resolver) Me(ctx context.Context) (*userResolver, error) {
+	req := fromContext(ctx)
+
+	user, err := req.services.Authorization.GetUserById(req.userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+func (r *Resolver) Lists(ctx context.Context, args archivedArgs) ([]*listResolver, error) {
+	return getLists(ctx, args)
+}
+
+func (r *Resolver) List(ctx context.Context, args idArgs) (*listResolver, error) {
+	listId, err := parseId(args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return getList(ctx, listId)
+}
+
+func (r *Resolver) Item(ctx context.Context, args idArgs) (*itemResolver, error) {
+	itemId, err := parseId(args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return getItem(ctx, itemId)
+}
+
+type createListArgs struct {
+	Input struct {
+		Title       string
+		Description *string
+	}
+}
+
+func (r *Resolver) CreateList(ctx context.Context, args createListArgs) (*listResolver, error) {
+	req := fromContext(ctx)
+
+	list := todo.TodoList{Title: args.Input.Title}
+	if args.Input.Description != nil {
+		list.Description = *args.Input.Description
+	}
+
+	listId, err := req.services.TodoList.Create(req.userId, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return getList(ctx, listId)
+}
+
+type updateListArgs struct {
+	Id    graphql.ID
+	Input struct {
+		Title       *string
+		Description *string
+		IsTemplate  *bool
+	}
+	Version *int32
+}
+
+func (r *Resolver) UpdateList(ctx context.Context, args updateListArgs) (*listResolver, error) {
+	req := fromContext(ctx)
+
+	listId, err := parseId(args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	input := todo.UpdateListInput{Title: args.Input.Title, Description: args.Input.Description, IsTemplate: args.Input.IsTemplate}
+	if err := req.services.TodoList.Update(req.userId, listId, input, intValue(args.Version)); err != nil {
+		return nil, err
+	}
+
+	return getList(ctx, listId)
+}
+
+type deleteArgs struct {
+	Id      graphql.ID
+	Version *int32
+}
+
+func (r *Resolver) DeleteList(ctx context.Context, args deleteArgs) (bool, error) {
+	req := fromContext(ctx)
+
+	listId, err := parseId(args.Id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := req.services.TodoList.Delete(req.userId, listId, intValue(args.Version)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type createItemArgs struct {
+	ListId graphql.ID
+	Input  struct {
+		Title       string
+		Description *string
+		DueDate     *string
+	}
+}
+
+func (r *Resolver) CreateItem(ctx context.Context, args createItemArgs) (*itemResolver, error) {
+	req := fromContext(ctx)
+
+	listId, err := parseId(args.ListId)
+	if err != nil {
+		return nil, err
+	}
+
+	item := todo.TodoItem{Title: args.Input.Title, DueDate: args.Input.DueDate}
+	if args.Input.Description != nil {
+		item.Description = *args.Input.Description
+	}
+
+	if item.DueDate != nil {
+		if _, err := time.Parse(todo.DateLayout, *item.DueDate); err != nil {
+			return nil, fmt.Errorf("invalid dueDate %q", *item.DueDate)
+		}
+	}
+
+	itemId, err := req.services.TodoItem.Create(req.userId, listId, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return getItem(ctx, itemId)
+}
+
+type updateItemArgs struct {
+	Id    graphql.ID
+	Input struct {
+		Title       *string
+		Description *string
+		Done        *bool
+		DueDate     *string
+	}
+	Version *int32
+}
+
+func (r *Resolver) UpdateItem(ctx context.Context, args updateItemArgs) (*itemResolver, error) {
+	req := fromContext(ctx)
+
+	itemId, err := parseId(args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	input := todo.UpdateItemInput{
+		Title:       args.Input.Title,
+		Description: args.Input.Description,
+		Done:        args.Input.Done,
+		DueDate:     args.Input.DueDate,
+	}
+	if err := req.services.TodoItem.Update(req.userId, itemId, input, intValue(args.Version)); err != nil {
+		return nil, err
+	}
+
+	return getItem(ctx, itemId)
+}
+
+func (r *Resolver) DeleteItem(ctx context.Context, args deleteArgs) (bool, error) {
+	req := fromContext(ctx)
+
+	itemId, err := parseId(args.Id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := req.services.TodoItem.Delete(req.userId, itemId, intValue(args.Version)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func getLists(ctx context.Context, args archivedArgs) ([]*listResolver, error) {
+	req := fromContext(ctx)
+
+	lists, _, err := req.services.TodoList.GetAll(req.userId, todo.ListFilter{IncludeArchived: args.IncludeArchived})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*listResolver, len(lists))
+	listIds := make([]int, len(lists))
+	for i, list := range lists {
+		resolvers[i] = &listResolver{list: list}
+		listIds[i] = list.Id
+	}
+	req.items.prime(listIds...)
+
+	return resolvers, nil
+}
+
+func getList(ctx context.Context, listId int) (*listResolver, error) {
+	req := fromContext(ctx)
+
+	list, err := req.services.TodoList.GetById(req.userId, listId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listResolver{list: list}, nil
+}
+
+func getItem(ctx context.Context, itemId int) (*itemResolver, error) {
+	req := fromContext(ctx)
+
+	item, err := req.services.TodoItem.GetById(req.userId, itemId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &itemResolver{item: item}, nil
+}
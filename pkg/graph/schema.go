@@ -0,0 +1,108 @@
+// Package graph serves the lists and items of the user over GraphQL, resolving
+// every field through the same services as the REST API.
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/zhashkevych/todo-app/pkg/service"
+)
+
+const maxDepth = 10
+
+const schemaString = `
+schema {
+	query: Query
+	mutation: Mutation
+}
+
+scalar Time
+
+type Query {
+	me: User!
+	lists(includeArchived: Boolean = false): [List!]!
+	list(id: ID!): List!
+	item(id: ID!): Item!
+}
+
+type Mutation {
+	createList(input: CreateListInput!): List!
+	updateList(id: ID!, input: UpdateListInput!, version: Int): List!
+	deleteList(id: ID!, version: Int): Boolean!
+	createItem(listId: ID!, input: CreateItemInput!): Item!
+	updateItem(id: ID!, input: UpdateItemInput!, version: Int): Item!
+	deleteItem(id: ID!, version: Int): Boolean!
+}
+
+type User {
+	id: ID!
+	name: String!
+	username: String!
+	lists(includeArchived: Boolean = false): [List!]!
+}
+
+type List {
+	id: ID!
+	title: String!
+	description: String!
+	position: String!
+	isTemplate: Boolean!
+	archived: Boolean!
+	version: Int!
+	createdAt: Time!
+	updatedAt: Time!
+	items(includeArchived: Boolean = false): [Item!]!
+}
+
+type Item {
+	id: ID!
+	listId: ID!
+	parentId: ID
+	title: String!
+	description: String!
+	done: Boolean!
+	position: String!
+	archived: Boolean!
+	dueDate: String
+	version: Int!
+	createdAt: Time!
+	updatedAt: Time!
+	completedAt: Time
+}
+
+input CreateListInput {
+	title: String!
+	description: String
+}
+
+input UpdateListInput {
+	title: String
+	description: String
+	isTemplate: Boolean
+}
+
+input CreateItemInput {
+	title: String!
+	description: String
+	dueDate: String
+}
+
+input UpdateItemInput {
+	title: String
+	description: String
+	done: Boolean
+	dueDate: String
+}
+`
+
+var schema = graphql.MustParseSchema(schemaString, &Resolver{}, graphql.MaxDepth(maxDepth))
+
+// Execute runs the query for the user. Every query gets its own loaders,
+// so nothing is cached between queries.
+func Execute(ctx context.Context, services *service.Service, userId int, query, operationName string,
+	variables map[string]interface{}) *graphql.Response {
+	ctx = context.WithValue(ctx, requestKey{}, newRequest(services, userId))
+
+	return schema.Exec(ctx, query, operationName, variables)
+}
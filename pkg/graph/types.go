@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/zhashkevych/todo-app"
+)
+
+type userResolver struct {
+	user todo.User
+}
+
+func (r *userResolver) Id() graphql.ID {
+	return formatId(r.user.Id)
+}
+
+func (r *userResolver) Name() string {
+	return r.user.Name
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *userResolver) Lists(ctx context.Context, args archivedArgs) ([]*listResolver, error) {
+	return getLists(ctx, args)
+}
+
+type listResolver struct {
+	list todo.TodoList
+}
+
+func (r *listResolver) Id() graphql.ID {
+	return formatId(r.list.Id)
+}
+
+func (r *listResolver) Title() string {
+	return r.list.Title
+}
+
+func (r *listResolver) Description() string {
+	return r.list.Description
+}
+
+func (r *listResolver) Position() string {
+	return r.list.Position
+}
+
+func (r *listResolver) IsTemplate() bool {
+	return r.list.IsTemplate
+}
+
+func (r *listResolver) Archived() bool {
+	return r.list.Archived
+}
+
+func (r *listResolver) Version() int32 {
+	return int32(r.list.Version)
+}
+
+func (r *listResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.list.CreatedAt}
+}
+
+func (r *listResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.list.UpdatedAt}
+}
+
+// Items returns all items of the list including subtasks, which refer to their parents by parentId.
+func (r *listResolver) Items(ctx context.Context, args archivedArgs) ([]*itemResolver, error) {
+	items, err := fromContext(ctx).items.load(r.list.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*itemResolver, 0, len(items))
+	for _, item := range items {
+		if item.Archived && !args.IncludeArchived {
+			continue
+		}
+		resolvers = append(resolvers, &itemResolver{item: item})
+	}
+
+	return resolvers, nil
+}
+
+type itemResolver struct {
+	item todo.TodoItem
+}
+
+func (r *itemResolver) Id() graphql.ID {
+	return formatId(r.item.Id)
+}
+
+func (r *itemResolver) ListId() graphql.ID {
+	return formatId(r.item.ListId)
+}
+
+func (r *itemResolver) ParentId() *graphql.ID {
+	if r.item.ParentId == nil {
+		return nil
+	}
+
+	id := formatId(*r.item.ParentId)
+	return &id
+}
+
+func (r *itemResolver) Title() string {
+	return r.item.Title
+}
+
+func (r *itemResolver) Description() string {
+	return r.item.Description
+}
+
+func (r *itemResolver) Done() bool {
+	return r.item.Done
+}
+
+func (r *itemResolver) Position() string {
+	return r.item.Position
+}
+
+func (r *itemResolver) Archived() bool {
+	return r.item.Archived
+}
+
+func (r *itemResolver) DueDate() *string {
+	return r.item.DueDate
+}
+
+func (r *itemResolver) Version() int32 {
+	return int32(r.item.Version)
+}
+
+func (r *itemResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.item.CreatedAt}
+}
+
+func (r *itemResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.item.UpdatedAt}
+}
+
+func (r *itemResolver) CompletedAt() *graphql.Time {
+	if r.item.CompletedAt == nil {
+		return nil
+	}
+
+	return &graphql.Time{Time: *r.item.CompletedAt}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+)
+
+type getActivityResponse struct {
+	Data []todo.Activity `json:"data"`
+}
+
+// @Summary Get Activity
+// @Security ApiKeyAuth
+// @Tags activity
+// @Description get changes of all lists of the user, newest first
+// @ID get-activity
+// @Accept  json
+// @Produce  json
+// @Param limit query int false "page size, 50 by default"
+// @Param offset query int false "number of entries to skip"
+// @Success 200 {object} getActivityResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/activity [get]
+func (h *Handler) getAllActivity(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var filter todo.ActivityFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	activity, err := h.services.Activity.GetAll(userId, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondPage(c, getActivityResponse{Data: activity}, activity, filter.Limit, filter.Offset, nil)
+}
+
+// @Summary Get List Activity
+// @Security ApiKeyAuth
+// @Tags activity
+// @Description get changes of the list and its items, newest first
+// @ID get-list-activity
+// @Accept  json
+// @Produce  json
+// @Param id path int true "list id"
+// @Param limit query int false "page size, 50 by default"
+// @Param offset query int false "number of entries to skip"
+// @Success 200 {object} getActivityResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists/{id}/activity [get]
+func (h *Handler) getListActivity(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid list id param")
+		return
+	}
+
+	var filter todo.ActivityFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	activity, err := h.services.Activity.GetByList(userId, listId, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondPage(c, getActivityResponse{Data: activity}, activity, filter.Limit, filter.Offset, nil)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_getListActivity(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockActivity, filter todo.ActivityFilter)
+
+	actorId, itemId, version := 1, 2, 3
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		query                string
+		filter               todo.ActivityFilter
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Ok",
+			query:  "?limit=10&offset=20",
+			filter: todo.ActivityFilter{Limit: 10, Offset: 20},
+			mockBehavior: func(r *service_mocks.MockActivity, filter todo.ActivityFilter) {
+				r.EXPECT().GetByList(1, 1, filter).Return([]todo.Activity{
+					{
+						Id:        3,
+						ActorId:   &actorId,
+						ListId:    1,
+						ItemId:    &itemId,
+						Action:    todo.ActivityUpdate,
+						Changes:   todo.Changes{"done": {From: false, To: true}},
+						Version:   &version,
+						CreatedAt: createdAt,
+					},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"data":[{"id":3,"actor_id":1,"list_id":1,"item_id":2,"action":"update",` +
+				`"changes":{"done":{"from":false,"to":true}},"version":3,"created_at":"2021-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name:                 "Invalid Limit",
+			query:                "?limit=1000",
+			mockBehavior:         func(r *service_mocks.MockActivity, filter todo.ActivityFilter) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"Key: 'ActivityFilter.Limit' Error:Field validation for 'Limit' failed on the 'max' tag"}`,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(r *service_mocks.MockActivity, filter todo.ActivityFilter) {
+				r.EXPECT().GetByList(1, 1, filter).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockActivity(c)
+			test.mockBehavior(repo, test.filter)
+
+			services := &service.Service{Activity: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/lists/:id/activity", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.getListActivity)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/lists/1/activity"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
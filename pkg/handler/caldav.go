@@ -0,0 +1,405 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/ical"
+)
+
+// A minimal CalDAV (RFC 4791) server exposing every list of the user as a calendar collection
+// of VTODO resources. Clients can read the items and complete them or mark them as not done
+// with PUT, other changes of the resources are ignored and new resources can't be created.
+
+const (
+	depthHeader = "Depth"
+
+	caldavItemSuffix  = ".ics"
+	caldavContentType = "text/calendar; charset=utf-8; component=vtodo"
+)
+
+// caldavTarget is the resource a CalDAV request is made to: the collection of all lists
+// if ListId is zero, the collection of a list if ItemId is zero and an item otherwise.
+type caldavTarget struct {
+	ListId int
+	ItemId int
+}
+
+func parseCalDAVTarget(path string) (caldavTarget, bool) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return caldavTarget{}, true
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 {
+		return caldavTarget{}, false
+	}
+
+	listId, err := strconv.Atoi(parts[0])
+	if err != nil || listId <= 0 {
+		return caldavTarget{}, false
+	}
+
+	if len(parts) == 1 {
+		return caldavTarget{ListId: listId}, true
+	}
+
+	if !strings.HasSuffix(parts[1], caldavItemSuffix) {
+		return caldavTarget{}, false
+	}
+
+	itemId, err := strconv.Atoi(strings.TrimSuffix(parts[1], caldavItemSuffix))
+	if err != nil || itemId <= 0 {
+		return caldavTarget{}, false
+	}
+
+	return caldavTarget{ListId: listId, ItemId: itemId}, true
+}
+
+// caldavResponse is a resource of a multistatus response with its properties as XML.
+type caldavResponse struct {
+	Href  string
+	Props string
+}
+
+func caldavHome(c *gin.Context) string {
+	return fmt.Sprintf("/caldav/%s/lists/", c.Param("token"))
+}
+
+func caldavListHref(c *gin.Context, listId int) string {
+	return fmt.Sprintf("%s%d/", caldavHome(c), listId)
+}
+
+func caldavItemHref(c *gin.Context, item todo.TodoItem) string {
+	return fmt.Sprintf("%s%d%s", caldavListHref(c, item.ListId), item.Id, caldavItemSuffix)
+}
+
+func escapeXML(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
+
+func listProps(list todo.TodoList, items []todo.TodoItem) string {
+	props := fmt.Sprintf(`<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>`+
+		`<d:displayname>%s</d:displayname>`+
+		`<c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set>`,
+		escapeXML(list.Title))
+
+	if items != nil {
+		// the tag changes whenever the list or any of its items change
+		var latest int64
+		for _, item := range items {
+			if t := item.UpdatedAt.UnixNano(); t > latest {
+				latest = t
+			}
+		}
+		props += fmt.Sprintf(`<cs:getctag>%d-%d-%d</cs:getctag>`, list.Version, len(items), latest)
+	}
+
+	return props
+}
+
+func itemProps(item todo.TodoItem) string {
+	return fmt.Sprintf(`<d:getetag>%s</d:getetag><d:getcontenttype>%s</d:getcontenttype>`,
+		escapeXML(formatETag(item.Version)), caldavContentType)
+}
+
+func writeMultistatus(c *gin.Context, responses []caldavResponse) {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>` +
+		`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+	for _, r := range responses {
+		fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop>%s</d:prop>`+
+			`<d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`, escapeXML(r.Href), r.Props)
+	}
+	b.WriteString(`</d:multistatus>`)
+
+	c.Data(http.StatusMultiStatus, "application/xml; charset=utf-8", []byte(b.String()))
+}
+
+// caldavErrorStatus maps missing lists and items to 404, clients rely on it.
+func caldavErrorStatus(c *gin.Context, err error) int {
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.StatusNotFound
+	}
+
+	return errorStatus(c, err)
+}
+
+func (h *Handler) caldavOptions(c *gin.Context) {
+	c.Header("DAV", "1, calendar-access")
+	c.Header("Allow", "OPTIONS, GET, PUT, PROPFIND, REPORT")
+	c.Status(http.StatusOK)
+}
+
+// getCalDAVItem returns the target item, which has to belong to the target list.
+func (h *Handler) getCalDAVItem(userId int, target caldavTarget) (todo.TodoItem, error) {
+	item, err := h.services.TodoItem.GetById(userId, target.ItemId)
+	if err != nil {
+		return item, err
+	}
+
+	if item.ListId != target.ListId || item.Archived {
+		return item, sql.ErrNoRows
+	}
+
+	return item, nil
+}
+
+func (h *Handler) caldavPropfind(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	target, ok := parseCalDAVTarget(c.Param("path"))
+	if !ok {
+		newErrorResponse(c, http.StatusNotFound, "resource not found")
+		return
+	}
+	children := c.GetHeader(depthHeader) != "0"
+
+	var responses []caldavResponse
+	switch {
+	case target.ListId == 0:
+		responses = append(responses, caldavResponse{
+			Href:  caldavHome(c),
+			Props: `<d:resourcetype><d:collection/></d:resourcetype><d:displayname>Lists</d:displayname>`,
+		})
+
+		if children {
+			lists, err := h.services.Calendar.GetLists(userId)
+			if err != nil {
+				newCodedErrorResponse(c, caldavErrorStatus(c, err), err)
+				return
+			}
+
+			for _, list := range lists {
+				responses = append(responses, caldavResponse{Href: caldavListHref(c, list.Id), Props: listProps(list, nil)})
+			}
+		}
+	case target.ItemId == 0:
+		list, err := h.services.Calendar.GetList(userId, target.ListId)
+		if err != nil {
+			newCodedErrorResponse(c, caldavErrorStatus(c, err), err)
+			return
+		}
+
+		responses = append(responses, caldavResponse{Href: caldavListHref(c, list.Id), Props: listProps(list.TodoList, list.Items)})
+		if children {
+			for _, item := range list.Items {
+				responses = append(responses, caldavResponse{Href: caldavItemHref(c, item), Props: itemProps(item)})
+			}
+		}
+	default:
+		item, err := h.getCalDAVItem(userId, target)
+		if err != nil {
+			newCodedErrorResponse(c, caldavErrorStatus(c, err), err)
+			return
+		}
+
+		responses = append(responses, caldavResponse{Href: caldavItemHref(c, item), Props: itemProps(item)})
+	}
+
+	writeMultistatus(c, responses)
+}
+
+// caldavReport answers calendar-query reports with all items of the list, filters aren't
+// supported, and calendar-multiget reports with the requested items.
+func (h *Handler) caldavReport(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	target, ok := parseCalDAVTarget(c.Param("path"))
+	if !ok || target.ListId == 0 || target.ItemId != 0 {
+		newErrorResponse(c, http.StatusNotFound, "resource not found")
+		return
+	}
+
+	report, hrefs, err := parseReport(c.Request.Body)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := h.services.Calendar.GetList(userId, target.ListId)
+	if err != nil {
+		newCodedErrorResponse(c, caldavErrorStatus(c, err), err)
+		return
+	}
+
+	responses := make([]caldavResponse, 0, len(list.Items))
+	for _, item := range list.Items {
+		href := caldavItemHref(c, item)
+		if report == "calendar-multiget" && !hrefs[href] {
+			continue
+		}
+
+		var data bytes.Buffer
+		if err := ical.WriteCalendar(&data, "", []todo.TodoItem{item}); err != nil {
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		responses = append(responses, caldavResponse{
+			Href:  href,
+			Props: itemProps(item) + "<c:calendar-data>" + escapeXML(data.String()) + "</c:calendar-data>",
+		})
+	}
+
+	writeMultistatus(c, responses)
+}
+
+// parseReport returns the name of the report and the hrefs it contains.
+func parseReport(body io.Reader) (string, map[string]bool, error) {
+	var report string
+	hrefs := make(map[string]bool)
+
+	decoder := xml.NewDecoder(body)
+	inHref := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid report: %s", err.Error())
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if report == "" {
+				report = t.Name.Local
+			}
+			inHref = t.Name.Local == "href"
+		case xml.EndElement:
+			inHref = false
+		case xml.CharData:
+			if inHref {
+				hrefs[strings.TrimSpace(string(t))] = true
+			}
+		}
+	}
+
+	return report, hrefs, nil
+}
+
+func (h *Handler) caldavGet(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	target, ok := parseCalDAVTarget(c.Param("path"))
+	if !ok || target.ListId == 0 {
+		newErrorResponse(c, http.StatusNotFound, "resource not found")
+		return
+	}
+
+	var name string
+	var items []todo.TodoItem
+	if target.ItemId == 0 {
+		list, err := h.services.Calendar.GetList(userId, target.ListId)
+		if err != nil {
+			newCodedErrorResponse(c, caldavErrorStatus(c, err), err)
+			return
+		}
+		name, items = list.Title, list.Items
+	} else {
+		item, err := h.getCalDAVItem(userId, target)
+		if err != nil {
+			newCodedErrorResponse(c, caldavErrorStatus(c, err), err)
+			return
+		}
+
+		if notModified(c, formatETag(item.Version)) {
+			return
+		}
+		items = []todo.TodoItem{item}
+	}
+
+	var data bytes.Buffer
+	if err := ical.WriteCalendar(&data, name, items); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Data(http.StatusOK, ical.ContentType, data.Bytes())
+}
+
+// caldavPut completes the item or marks it as not done according to the status of the VTODO.
+func (h *Handler) caldavPut(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	target, ok := parseCalDAVTarget(c.Param("path"))
+	if !ok || target.ItemId == 0 {
+		newErrorResponse(c, http.StatusMethodNotAllowed, "only items can be written")
+		return
+	}
+
+	version, err := getIfMatch(c)
+	if err != nil {
+		newCodedErrorResponse(c, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	vtodo, err := ical.ParseTodo(c.Request.Body)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := h.getCalDAVItem(userId, target)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			newErrorResponse(c, http.StatusForbidden, "items can't be created over CalDAV")
+			return
+		}
+		newCodedErrorResponse(c, caldavErrorStatus(c, err), err)
+		return
+	}
+
+	if vtodo.Uid != "" && vtodo.Uid != ical.Uid(item.Id) {
+		newErrorResponse(c, http.StatusBadRequest, "uid does not match the item")
+		return
+	}
+
+	done := vtodo.Done()
+	if done == item.Done {
+		if version != nil && *version != item.Version {
+			newCodedErrorResponse(c, http.StatusPreconditionFailed, todo.ErrVersionMismatch)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	if err := h.services.TodoItem.Update(userId, item.Id, todo.UpdateItemInput{Done: &done}, version); err != nil {
+		newCodedErrorResponse(c, caldavErrorStatus(c, err), err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
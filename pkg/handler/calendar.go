@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app/pkg/ical"
+)
+
+type calendarTokenResponse struct {
+	Token     string `json:"token"`
+	FeedUrl   string `json:"feed_url"`
+	CalDAVUrl string `json:"caldav_url"`
+}
+
+// @Summary Create Calendar Token
+// @Security ApiKeyAuth
+// @Tags calendar
+// @Description create a token for the calendar feed and CalDAV access, revoking the previous one
+// @ID create-calendar-token
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} calendarTokenResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/calendar/token [post]
+func (h *Handler) createCalendarToken(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	token, err := h.services.Calendar.CreateToken(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	base := fmt.Sprintf("%s://%s", scheme, c.Request.Host)
+
+	c.JSON(http.StatusOK, calendarTokenResponse{
+		Token:     token,
+		FeedUrl:   fmt.Sprintf("%s/calendar/%s/todo.ics", base, token),
+		CalDAVUrl: fmt.Sprintf("%s/caldav/%s/lists/", base, token),
+	})
+}
+
+// @Summary Delete Calendar Token
+// @Security ApiKeyAuth
+// @Tags calendar
+// @Description revoke the calendar token
+// @ID delete-calendar-token
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/calendar/token [delete]
+func (h *Handler) deleteCalendarToken(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.services.Calendar.DeleteToken(userId); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondDeleted(c)
+}
+
+// @Summary Get Calendar Feed
+// @Tags calendar
+// @Description get the items with due dates as VTODO components of an iCalendar feed
+// @ID get-calendar-feed
+// @Produce  text/calendar
+// @Param token path string true "calendar token"
+// @Success 200 {string} string "iCalendar object"
+// @Failure 401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /calendar/{token}/todo.ics [get]
+func (h *Handler) getCalendarFeed(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items, err := h.services.Calendar.GetDueItems(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Header("Content-Type", ical.ContentType)
+	c.Status(http.StatusOK)
+
+	if err := ical.WriteCalendar(c.Writer, "Todo", items); err != nil {
+		logrus.Errorf("error occured while writing calendar: %s", err.Error())
+	}
+}
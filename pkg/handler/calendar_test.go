@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_getCalendarFeed(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockCalendar)
+
+	updatedAt := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	dueDate := "2021-01-03"
+
+	tests := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *service_mocks.MockCalendar) {
+				r.EXPECT().GetUserId("token").Return(1, nil)
+				r.EXPECT().GetDueItems(1).Return([]todo.TodoItem{
+					{Id: 1, Title: "Bread", Version: 1, DueDate: &dueDate, CreatedAt: updatedAt, UpdatedAt: updatedAt},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//todo-app//todo-app//EN\r\nCALSCALE:GREGORIAN\r\n" +
+				"X-WR-CALNAME:Todo\r\nBEGIN:VTODO\r\nUID:item-1@todo-app\r\nDTSTAMP:20210101T100000Z\r\n" +
+				"CREATED:20210101T100000Z\r\nLAST-MODIFIED:20210101T100000Z\r\nSEQUENCE:1\r\nSUMMARY:Bread\r\n" +
+				"DUE;VALUE=DATE:20210103\r\nSTATUS:NEEDS-ACTION\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+		},
+		{
+			name: "Invalid Token",
+			mockBehavior: func(r *service_mocks.MockCalendar) {
+				r.EXPECT().GetUserId("token").Return(0, sql.ErrNoRows)
+			},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"message":"invalid calendar token"}`,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(r *service_mocks.MockCalendar) {
+				r.EXPECT().GetUserId("token").Return(1, nil)
+				r.EXPECT().GetDueItems(1).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockCalendar(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{Calendar: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/calendar/:token/todo.ics", handler.calendarIdentity, handler.getCalendarFeed)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/calendar/token/todo.ics", nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_caldavPut(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem)
+
+	completed := "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:item-2@todo-app\r\nSTATUS:COMPLETED\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+	done := true
+	version, staleVersion := 3, 2
+
+	tests := []struct {
+		name                 string
+		path                 string
+		ifMatch              string
+		body                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "Ok",
+			path:    "/lists/1/2.ics",
+			ifMatch: `"3"`,
+			body:    completed,
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{Id: 2, ListId: 1, Version: 3}, nil)
+				r.EXPECT().Update(1, 2, todo.UpdateItemInput{Done: &done}, &version).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:    "Unchanged",
+			path:    "/lists/1/2.ics",
+			ifMatch: `"3"`,
+			body:    completed,
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{Id: 2, ListId: 1, Done: true, Version: 3}, nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:    "Version Mismatch",
+			path:    "/lists/1/2.ics",
+			ifMatch: `"2"`,
+			body:    completed,
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{Id: 2, ListId: 1, Version: 3}, nil)
+				r.EXPECT().Update(1, 2, todo.UpdateItemInput{Done: &done}, &staleVersion).Return(todo.ErrVersionMismatch)
+			},
+			expectedStatusCode:   412,
+			expectedResponseBody: `{"message":"resource has been modified","code":"version_mismatch"}`,
+		},
+		{
+			name: "Item Of Other List",
+			path: "/lists/3/2.ics",
+			body: completed,
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{Id: 2, ListId: 1, Version: 3}, nil)
+			},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"message":"items can't be created over CalDAV"}`,
+		},
+		{
+			name:                 "Invalid Body",
+			path:                 "/lists/1/2.ics",
+			body:                 "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+			mockBehavior:         func(r *service_mocks.MockTodoItem) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"ical: no VTODO component"}`,
+		},
+		{
+			name:                 "Collection",
+			path:                 "/lists/1/",
+			body:                 completed,
+			mockBehavior:         func(r *service_mocks.MockTodoItem) {},
+			expectedStatusCode:   405,
+			expectedResponseBody: `{"message":"only items can be written"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{TodoItem: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.PUT("/caldav/:token/lists/*path", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.caldavPut)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/caldav/token"+test.path, strings.NewReader(test.body))
+			if test.ifMatch != "" {
+				req.Header.Set("If-Match", test.ifMatch)
+			}
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_caldavPropfind(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	updatedAt := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	repo := service_mocks.NewMockCalendar(c)
+	repo.EXPECT().GetList(1, 1).Return(todo.ListExport{
+		TodoList: todo.TodoList{Id: 1, Title: "Groceries & more", Version: 2},
+		Items:    []todo.TodoItem{{Id: 2, ListId: 1, Title: "Bread", Version: 3, UpdatedAt: updatedAt}},
+	}, nil)
+
+	handler := Handler{&service.Service{Calendar: repo}}
+
+	r := gin.New()
+	r.Handle("PROPFIND", "/caldav/:token/lists/*path", func(c *gin.Context) {
+		c.Set(userCtx, 1)
+	}, handler.caldavPropfind)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PROPFIND", "/caldav/token/lists/1/", nil)
+	req.Header.Set("Depth", "1")
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 207, w.Code)
+	assert.Equal(t, `<?xml version="1.0" encoding="utf-8"?>`+
+		`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`+
+		`<d:response><d:href>/caldav/token/lists/1/</d:href><d:propstat><d:prop>`+
+		`<d:resourcetype><d:collection/><c:calendar/></d:resourcetype><d:displayname>Groceries &amp; more</d:displayname>`+
+		`<c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set>`+
+		`<cs:getctag>2-1-1609495200000000000</cs:getctag>`+
+		`</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`+
+		`<d:response><d:href>/caldav/token/lists/1/2.ics</d:href><d:propstat><d:prop>`+
+		`<d:getetag>&#34;3&#34;</d:getetag><d:getcontenttype>text/calendar; charset=utf-8; component=vtodo</d:getcontenttype>`+
+		`</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`+
+		`</d:multistatus>`, w.Body.String())
+}
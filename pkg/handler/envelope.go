@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+)
+
+// The response contract of v2: created resources are returned with 201 and their location,
+// changed resources are returned in full, deletes respond with 204 and collections are
+// wrapped in data with pagination metadata. v1 keeps responding with ids and statuses.
+
+const (
+	locationHeader = "Location"
+
+	defaultPageSize = 50
+)
+
+// pagination describes the page of a collection. Total is omitted
+// for collections that aren't counted, such as the activity log.
+type pagination struct {
+	Limit  int  `json:"limit"`
+	Offset int  `json:"offset"`
+	Total  *int `json:"total,omitempty"`
+}
+
+type collectionResponse struct {
+	Data       interface{} `json:"data"`
+	Pagination pagination  `json:"pagination"`
+}
+
+// loader loads a resource after it has been created or changed, only v2 responds with it.
+type loader func() (interface{}, error)
+
+func (h *Handler) listLoader(userId, listId int) loader {
+	return func() (interface{}, error) {
+		return h.services.TodoList.GetById(userId, listId)
+	}
+}
+
+func (h *Handler) itemLoader(userId, itemId int) loader {
+	return func() (interface{}, error) {
+		return h.services.TodoItem.GetById(userId, itemId)
+	}
+}
+
+func (h *Handler) tagLoader(userId, tagId int) loader {
+	return func() (interface{}, error) {
+		return h.services.Tag.GetById(userId, tagId)
+	}
+}
+
+func (h *Handler) webhookLoader(userId, webhookId int) loader {
+	return func() (interface{}, error) {
+		return h.services.Webhook.GetById(userId, webhookId)
+	}
+}
+
+// respondCreated responds with the id of the created resource in v1 and
+// with the resource and its location under /api/v2/<collection> since v2.
+func respondCreated(c *gin.Context, id int, collection string, load loader) {
+	if isV1(c) {
+		c.JSON(http.StatusOK, map[string]interface{}{
+			"id": id,
+		})
+		return
+	}
+
+	c.Header(locationHeader, fmt.Sprintf("%s/%s/%d", v2Prefix, collection, id))
+	respondResource(c, http.StatusCreated, load)
+}
+
+// respondUpdated responds with the ok status in v1 and with the changed resource since v2.
+func respondUpdated(c *gin.Context, load loader) {
+	if isV1(c) {
+		c.JSON(http.StatusOK, statusResponse{"ok"})
+		return
+	}
+
+	respondResource(c, http.StatusOK, load)
+}
+
+// respondDeleted responds with the ok status in v1 and without a body since v2.
+func respondDeleted(c *gin.Context) {
+	if isV1(c) {
+		c.JSON(http.StatusOK, statusResponse{"ok"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondResource writes the resource with the ETag of its version, if it has one.
+func respondResource(c *gin.Context, status int, load loader) {
+	resource, err := load()
+	if err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	switch r := resource.(type) {
+	case todo.TodoList:
+		c.Header(etagHeader, formatETag(r.Version))
+	case todo.TodoItem:
+		c.Header(etagHeader, itemETag(r))
+	}
+
+	c.JSON(status, resource)
+}
+
+// collectionPage returns the page of a collection to load. v1 responds with whole
+// collections, since v2 a page of the default size is loaded unless a limit is given.
+func collectionPage(c *gin.Context, page todo.Page) todo.Page {
+	if isV1(c) {
+		return todo.Page{}
+	}
+
+	if page.Limit == 0 {
+		page.Limit = defaultPageSize
+	}
+
+	return page
+}
+
+// respondCollection responds with v1Response in v1 and with the page of the collection,
+// a slice, selected by the limit and offset query parameters since v2. It's meant for
+// small collections that are loaded whole, larger ones are paginated by the database.
+func respondCollection(c *gin.Context, v1Response interface{}, collection interface{}) {
+	if isV1(c) {
+		c.JSON(http.StatusOK, v1Response)
+		return
+	}
+
+	var query todo.Page
+	if err := c.BindQuery(&query); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPageSize
+	}
+
+	all := reflect.ValueOf(collection)
+	total := all.Len()
+	start := query.Offset
+	if start > total {
+		start = total
+	}
+	end := start + query.Limit
+	if end > total {
+		end = total
+	}
+
+	// a new slice, so empty pages are encoded as [] rather than null
+	page := reflect.AppendSlice(reflect.MakeSlice(all.Type(), 0, end-start), all.Slice(start, end))
+
+	c.JSON(http.StatusOK, collectionResponse{
+		Data:       page.Interface(),
+		Pagination: pagination{Limit: query.Limit, Offset: query.Offset, Total: &total},
+	})
+}
+
+// respondPage responds with v1Response in v1 and with the page of a collection
+// already paginated by the database since v2. A zero limit is the default page size,
+// total is the size of the whole collection if it has been counted.
+func respondPage(c *gin.Context, v1Response interface{}, page interface{}, limit, offset int, total *int) {
+	if isV1(c) {
+		c.JSON(http.StatusOK, v1Response)
+		return
+	}
+
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+
+	data := reflect.ValueOf(page)
+	if data.IsNil() {
+		data = reflect.MakeSlice(data.Type(), 0, 0)
+	}
+
+	c.JSON(http.StatusOK, collectionResponse{
+		Data:       data.Interface(),
+		Pagination: pagination{Limit: limit, Offset: offset, Total: total},
+	})
+}
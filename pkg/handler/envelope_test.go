@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_v2Envelopes(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem)
+
+	items := []todo.TodoItem{{Id: 1, Title: "Bread"}, {Id: 2, Title: "Milk"}, {Id: 3, Title: "Eggs"}}
+
+	tests := []struct {
+		name                 string
+		method               string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}{
+		{
+			name:   "Page",
+			method: "GET",
+			path:   "/api/v2/items/?limit=1&offset=1",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetAllByUser(1, todo.ItemFilter{Page: todo.Page{Limit: 1, Offset: 1}}).Return(items[1:2], 3, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":2,"title":"Milk","description":"","done":false,"list_id":0,"parent_id":null,"position":"","archived":false,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","created_by":null,"updated_by":null,"completed_at":null,"due_date":null,"version":0}],"pagination":{"limit":1,"offset":1,"total":3}}`,
+		},
+		{
+			name:   "Page Past End",
+			method: "GET",
+			path:   "/api/v2/items/?offset=5",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetAllByUser(1, todo.ItemFilter{Page: todo.Page{Limit: 50, Offset: 5}}).Return(nil, 3, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[],"pagination":{"limit":50,"offset":5,"total":3}}`,
+		},
+		{
+			name:                 "Invalid Limit",
+			method:               "GET",
+			path:                 "/api/v2/items/?limit=101",
+			mockBehavior:         func(r *service_mocks.MockTodoItem) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"Key: 'ItemFilter.Page.Limit' Error:Field validation for 'Limit' failed on the 'max' tag"}`,
+		},
+		{
+			name:   "Update",
+			method: "POST",
+			path:   "/api/v2/items/2/archive",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Archive(1, 2).Return(nil)
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{Id: 2, Title: "Milk", Archived: true, Version: 4}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedETag:         `"4"`,
+			expectedResponseBody: `{"id":2,"title":"Milk","description":"","done":false,"list_id":0,"parent_id":null,"position":"","archived":true,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","created_by":null,"updated_by":null,"completed_at":null,"due_date":null,"version":4}`,
+		},
+		{
+			name:   "Update Load Failure",
+			method: "POST",
+			path:   "/api/v2/items/2/archive",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Archive(1, 2).Return(nil)
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"something went wrong"}`,
+		},
+		{
+			name:   "Delete",
+			method: "DELETE",
+			path:   "/api/v2/items/2",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Delete(1, 2, nil).Return(nil)
+			},
+			expectedStatusCode:   204,
+			expectedResponseBody: ``,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{TodoItem: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			api := r.Group(v2Prefix, apiVersion(2), func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			})
+			api.GET("/items/", handler.getUserItems)
+			api.POST("/items/:id/archive", handler.archiveItem)
+			api.DELETE("/items/:id", handler.deleteItem)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(""))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedETag, w.Header().Get("ETag"))
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
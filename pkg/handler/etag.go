@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+)
+
+const (
+	etagHeader        = "ETag"
+	ifMatchHeader     = "If-Match"
+	ifNoneMatchHeader = "If-None-Match"
+)
+
+// formatETag returns the strong entity tag of a resource version.
+func formatETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// itemETag returns the strong entity tag of an item. The progress of its subtasks is
+// part of the representation, but changes of subtasks don't change the item's version,
+// so the progress is added to the tag.
+func itemETag(item todo.TodoItem) string {
+	if item.Progress == nil {
+		return formatETag(item.Version)
+	}
+
+	return fmt.Sprintf(`"%d.%d.%d"`, item.Version, item.Progress.Total, item.Progress.Done)
+}
+
+// getIfMatch returns the version required by the If-Match header or nil if the header
+// is absent or is "*". Tags that can't match any version result in todo.ErrVersionMismatch.
+// The progress part of an item tag is ignored, the progress can't be changed by the request.
+func getIfMatch(c *gin.Context) (*int, error) {
+	header := strings.TrimSpace(c.GetHeader(ifMatchHeader))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return nil, todo.ErrVersionMismatch
+	}
+
+	tag := header[1 : len(header)-1]
+	if i := strings.IndexByte(tag, '.'); i >= 0 {
+		tag = tag[:i]
+	}
+
+	version, err := strconv.Atoi(tag)
+	if err != nil {
+		return nil, todo.ErrVersionMismatch
+	}
+
+	return &version, nil
+}
+
+// notModified sets the ETag header of the response and reports whether the If-None-Match
+// header of the request matches it. In that case the status is set to 304 and
+// the handler must not write a body.
+func notModified(c *gin.Context, tag string) bool {
+	c.Header(etagHeader, tag)
+
+	header := c.GetHeader(ifNoneMatchHeader)
+	if header == "" {
+		return false
+	}
+
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == tag || t == "*" {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
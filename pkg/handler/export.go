@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/export"
+)
+
+// @Summary Export List
+// @Security ApiKeyAuth
+// @Tags export
+// @Description export the list with all of its items as JSON, CSV, a Markdown checklist or todo.txt
+// @ID export-list
+// @Produce  json,text/csv,text/markdown,text/plain
+// @Param id path int true "list id"
+// @Param format query string false "json (default), csv, markdown or todotxt"
+// @Success 200 {object} todo.ListExport
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists/{id}/export [get]
+func (h *Handler) exportList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid list id param")
+		return
+	}
+
+	format, err := exportFormat(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := h.services.Export.GetList(userId, listId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setExportHeaders(c, format, fmt.Sprintf("list-%d", listId))
+	c.Status(http.StatusOK)
+
+	if err := export.Encode(c.Writer, format, list); err != nil {
+		logrus.Errorf("error occured while exporting list: %s", err.Error())
+	}
+}
+
+// @Summary Export Account
+// @Security ApiKeyAuth
+// @Tags export
+// @Description export all lists of the user with their items, streamed list by list
+// @ID export-account
+// @Produce  json,text/csv,text/markdown,text/plain
+// @Param format query string false "json (default), csv, markdown or todotxt"
+// @Success 200 {array} todo.ListExport
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/export [get]
+func (h *Handler) exportAll(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	format, err := exportFormat(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	encoder, err := export.NewEncoder(c.Writer, format)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setExportHeaders(c, format, "todo-export")
+	c.Status(http.StatusOK)
+
+	err = h.services.Export.ForEachList(userId, func(list todo.ListExport) error {
+		if err := encoder.Encode(list); err != nil {
+			return err
+		}
+
+		c.Writer.Flush()
+		return nil
+	})
+	if err == nil {
+		err = encoder.Close()
+	}
+
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// the response has been started, the client sees an incomplete export
+		logrus.Errorf("error occured while exporting lists: %s", err.Error())
+	}
+}
+
+func exportFormat(c *gin.Context) (string, error) {
+	var input todo.ExportInput
+	if err := c.BindQuery(&input); err != nil {
+		return "", err
+	}
+
+	if input.Format == "" {
+		return todo.FormatJSON, nil
+	}
+
+	return input.Format, nil
+}
+
+func setExportHeaders(c *gin.Context, format, name string) {
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, export.Extension(format)))
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_exportList(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockExport)
+
+	list := todo.ListExport{
+		TodoList: todo.TodoList{Id: 1, Title: "Groceries"},
+		Items: []todo.TodoItem{
+			{Id: 1, Title: "Bread", Position: "i"},
+			{Id: 2, Title: "Milk", Done: true, Position: "r"},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?format=markdown",
+			mockBehavior: func(r *service_mocks.MockExport) {
+				r.EXPECT().GetList(1, 1).Return(list, nil)
+			},
+			expectedStatusCode:   200,
+			expectedContentType:  "text/markdown; charset=utf-8",
+			expectedResponseBody: "# Groceries\n\n- [ ] Bread\n- [x] Milk\n",
+		},
+		{
+			name:                 "Invalid Format",
+			query:                "?format=xml",
+			mockBehavior:         func(r *service_mocks.MockExport) {},
+			expectedStatusCode:   400,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"Key: 'ExportInput.Format' Error:Field validation for 'Format' failed on the 'oneof' tag"}`,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(r *service_mocks.MockExport) {
+				r.EXPECT().GetList(1, 1).Return(todo.ListExport{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockExport(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{Export: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/lists/:id/export", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.exportList)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/lists/1/export"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_exportAll(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockExport)
+
+	lists := []todo.ListExport{
+		{TodoList: todo.TodoList{Id: 1, Title: "Groceries"}, Items: []todo.TodoItem{{Id: 1, Title: "Bread"}}},
+		{TodoList: todo.TodoList{Id: 2, Title: "Work"}},
+	}
+
+	tests := []struct {
+		name                       string
+		query                      string
+		mockBehavior               mockBehavior
+		expectedStatusCode         int
+		expectedContentDisposition string
+		expectedResponseBody       string
+	}{
+		{
+			name:  "Ok",
+			query: "?format=todotxt",
+			mockBehavior: func(r *service_mocks.MockExport) {
+				r.EXPECT().ForEachList(1, gomock.Any()).DoAndReturn(func(userId int, fn func(todo.ListExport) error) error {
+					for _, list := range lists {
+						if err := fn(list); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+			expectedStatusCode:         200,
+			expectedContentDisposition: `attachment; filename="todo-export.txt"`,
+			expectedResponseBody:       "0001-01-01 Bread +Groceries\n",
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(r *service_mocks.MockExport) {
+				r.EXPECT().ForEachList(1, gomock.Any()).Return(errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockExport(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{Export: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/export", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.exportAll)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/export"+test.query, nil)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedContentDisposition, w.Header().Get("Content-Disposition"))
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app/pkg/graph"
+)
+
+type graphqlRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// @Summary GraphQL
+// @Security ApiKeyAuth
+// @Tags graphql
+// @Description run a GraphQL query or mutation over the user, lists and items
+// @ID graphql
+// @Accept  json
+// @Produce  json
+// @Param input body graphqlRequest true "query"
+// @Success 200 {object} object "data and errors of the query"
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /graphql [post]
+func (h *Handler) graphql(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var input graphqlRequest
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// errors of the query are reported in the response with status 200, as GraphQL clients expect
+	response := graph.Execute(c.Request.Context(), h.services, userId, input.Query, input.OperationName, input.Variables)
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_graphql(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"query":"{ me { id username } }"}`,
+			mockBehavior: func(r *service_mocks.MockAuthorization) {
+				r.EXPECT().GetUserById(1).Return(todo.User{Id: 1, Name: "Test", Username: "test"}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":{"me":{"id":"1","username":"test"}}}`,
+		},
+		{
+			name:                 "Invalid Query",
+			inputBody:            `{"query":"{ me { password } }"}`,
+			mockBehavior:         func(r *service_mocks.MockAuthorization) {},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"errors":[{"message":"Cannot query field \"password\" on type \"User\".","locations":[{"line":1,"column":8}]}]}`,
+		},
+		{
+			name:                 "Missing Query",
+			inputBody:            `{"variables":{}}`,
+			mockBehavior:         func(r *service_mocks.MockAuthorization) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"Key: 'graphqlRequest.Query' Error:Field validation for 'Query' failed on the 'required' tag"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockAuthorization(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{Authorization: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/graphql", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.graphql)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/graphql", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
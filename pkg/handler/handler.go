@@ -18,7 +18,7 @@ func NewHandler(services *service.Service) *Handler {
 	return &Handler{services: services}
 }
 
-func (h *Handler) InitRoutes() *gin.Engine {
+func (h *Handler) InitRoutes(v1 Deprecation) *gin.Engine {
 	router := gin.New()
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -29,30 +29,108 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		auth.POST("/sign-in", h.signIn)
 	}
 
-	api := router.Group("/api", h.userIdentity)
+	calendar := router.Group("/calendar/:token", h.calendarIdentity)
 	{
-		lists := api.Group("/lists")
+		calendar.GET("/todo.ics", h.getCalendarFeed)
+	}
+
+	caldav := router.Group("/caldav/:token", h.calendarIdentity)
+	{
+		caldav.OPTIONS("/lists/*path", h.caldavOptions)
+		caldav.Handle("PROPFIND", "/lists/*path", h.caldavPropfind)
+		caldav.Handle("REPORT", "/lists/*path", h.caldavReport)
+		caldav.GET("/lists/*path", h.caldavGet)
+		caldav.PUT("/lists/*path", h.caldavPut)
+	}
+
+	router.POST("/graphql", h.userIdentity, h.graphql)
+
+	// v1 keeps its response shapes until the sunset, new clients should use v2
+	apiV1 := router.Group(v1Prefix, deprecated(v1), apiVersion(1), h.userIdentity, h.idempotency)
+	h.initAPIRoutes(apiV1)
+
+	apiV2 := router.Group(v2Prefix, apiVersion(2), h.userIdentity, h.idempotency)
+	h.initAPIRoutes(apiV2)
+
+	return router
+}
+
+// initAPIRoutes registers the routes of every version of the API, the handlers
+// choose the shape of their responses by the version of the request.
+func (h *Handler) initAPIRoutes(api *gin.RouterGroup) {
+	lists := api.Group("/lists")
+	{
+		lists.POST("/", h.createList)
+		lists.GET("/", h.getAllLists)
+		lists.GET("/:id", h.getListById)
+		lists.PUT("/:id", h.updateList)
+		lists.PATCH("/:id", h.patchList)
+		lists.DELETE("/:id", h.deleteList)
+		lists.POST("/:id/move", h.moveList)
+		lists.POST("/:id/duplicate", h.duplicateList)
+		lists.POST("/:id/instantiate", h.instantiateList)
+		lists.POST("/:id/archive", h.archiveList)
+		lists.POST("/:id/unarchive", h.unarchiveList)
+		lists.POST("/:id/restore", h.restoreList)
+		lists.GET("/:id/activity", h.getListActivity)
+		lists.GET("/:id/export", h.exportList)
+
+		items := lists.Group(":id/items")
 		{
-			lists.POST("/", h.createList)
-			lists.GET("/", h.getAllLists)
-			lists.GET("/:id", h.getListById)
-			lists.PUT("/:id", h.updateList)
-			lists.DELETE("/:id", h.deleteList)
-
-			items := lists.Group(":id/items")
-			{
-				items.POST("/", h.createItem)
-				items.GET("/", h.getAllItems)
-			}
+			items.POST("/", h.createItem)
+			items.GET("/", h.getAllItems)
 		}
+	}
+
+	items := api.Group("items")
+	{
+		items.GET("/", h.getUserItems)
+		items.GET("/:id", h.getItemById)
+		items.PUT("/:id", h.updateItem)
+		items.PATCH("/:id", h.patchItem)
+		items.DELETE("/:id", h.deleteItem)
+		items.POST("/:id/items", h.createChildItem)
+		items.GET("/:id/items", h.getChildItems)
+		items.POST("/:id/move", h.moveItem)
+		items.POST("/:id/copy", h.copyItem)
+		items.POST("/:id/archive", h.archiveItem)
+		items.POST("/:id/unarchive", h.unarchiveItem)
+		items.POST("/:id/restore", h.restoreItem)
 
-		items := api.Group("items")
+		itemTags := items.Group(":id/tags")
 		{
-			items.GET("/:id", h.getItemById)
-			items.PUT("/:id", h.updateItem)
-			items.DELETE("/:id", h.deleteItem)
+			itemTags.GET("/", h.getItemTags)
+			itemTags.POST("/:tag_id", h.addItemTag)
+			itemTags.DELETE("/:tag_id", h.removeItemTag)
 		}
 	}
 
-	return router
+	tags := api.Group("tags")
+	{
+		tags.POST("/", h.createTag)
+		tags.GET("/", h.getAllTags)
+		tags.GET("/:id", h.getTagById)
+		tags.PUT("/:id", h.updateTag)
+		tags.DELETE("/:id", h.deleteTag)
+	}
+
+	webhooks := api.Group("webhooks")
+	{
+		webhooks.POST("/", h.createWebhook)
+		webhooks.GET("/", h.getAllWebhooks)
+		webhooks.GET("/:id", h.getWebhookById)
+		webhooks.DELETE("/:id", h.deleteWebhook)
+		webhooks.GET("/:id/deliveries", h.getWebhookDeliveries)
+		webhooks.POST("/:id/deliveries/:delivery_id/replay", h.replayWebhookDelivery)
+	}
+
+	api.GET("/trash", h.getTrash)
+	api.GET("/activity", h.getAllActivity)
+	api.GET("/stream", h.streamActivity)
+	api.GET("/export", h.exportAll)
+	api.POST("/import", h.importLists)
+	api.POST("/calendar/token", h.createCalendarToken)
+	api.DELETE("/calendar/token", h.deleteCalendarToken)
+	api.POST("/undo", h.undo)
+	api.POST("/bulk/items", h.bulkItems)
 }
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	idempotencyKeyMaxLength  = 255
+)
+
+// replayedHeaders are the response headers stored with the response and replayed with it.
+var replayedHeaders = []string{"Location", "ETag"}
+
+// responseRecorder keeps a copy of the response body written by the handlers.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotency stores the response to a POST request made with an Idempotency-Key header
+// and replays it when the request is retried with the same key and body.
+// Responses with server errors aren't stored, so such requests can be retried.
+func (h *Handler) idempotency(c *gin.Context) {
+	key := c.GetHeader(idempotencyKeyHeader)
+	if c.Request.Method != http.MethodPost || key == "" {
+		return
+	}
+
+	if len(key) > idempotencyKeyMaxLength {
+		newErrorResponse(c, http.StatusBadRequest, "idempotency key is too long")
+		return
+	}
+
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	stored, err := h.services.Idempotency.Begin(userId, key, requestHash(c.Request, body))
+	if err != nil {
+		switch {
+		case errors.Is(err, todo.ErrIdempotencyKeyReused):
+			newCodedErrorResponse(c, http.StatusUnprocessableEntity, err)
+		case errors.Is(err, todo.ErrRequestInProgress):
+			newCodedErrorResponse(c, http.StatusConflict, err)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	if stored != nil {
+		for name, value := range stored.ResponseHeaders {
+			c.Header(name, value)
+		}
+		c.Header(idempotentReplayedHeader, "true")
+		c.Data(*stored.StatusCode, gin.MIMEJSON+"; charset=utf-8", stored.ResponseBody)
+		c.Abort()
+		return
+	}
+
+	// release the key if the handler panics, otherwise it stays in progress until it expires
+	defer func() {
+		if r := recover(); r != nil {
+			if err := h.services.Idempotency.Release(userId, key); err != nil {
+				logrus.Errorf("error occured while releasing idempotency key: %s", err.Error())
+			}
+			panic(r)
+		}
+	}()
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	c.Next()
+
+	if recorder.Status() >= http.StatusInternalServerError {
+		err = h.services.Idempotency.Release(userId, key)
+	} else {
+		err = h.services.Idempotency.Complete(userId, key, recorder.Status(), responseHeaders(recorder.Header()),
+			recorder.body.Bytes())
+	}
+	if err != nil {
+		logrus.Errorf("error occured while saving idempotency key: %s", err.Error())
+	}
+}
+
+// responseHeaders returns the headers of the response to be replayed.
+func responseHeaders(header http.Header) todo.ResponseHeaders {
+	headers := make(todo.ResponseHeaders)
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	return headers
+}
+
+// requestHash identifies a request by its method, path and body.
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_idempotency(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockIdempotency)
+
+	statusCode := 200
+
+	tests := []struct {
+		name                 string
+		key                  string
+		inputBody            string
+		panics               bool
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedLocation     string
+		expectedReplayed     string
+	}{
+		{
+			name:      "Ok",
+			key:       "key",
+			inputBody: `{"title": "test"}`,
+			mockBehavior: func(r *service_mocks.MockIdempotency) {
+				r.EXPECT().Begin(1, "key", gomock.Any()).Return(nil, nil)
+				r.EXPECT().Complete(1, "key", 200, todo.ResponseHeaders{"Location": "/lists/1"}, []byte(`{"id":1}`)).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1}`,
+			expectedLocation:     "/lists/1",
+		},
+		{
+			name:                 "No Key",
+			inputBody:            `{"title": "test"}`,
+			mockBehavior:         func(r *service_mocks.MockIdempotency) {},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1}`,
+			expectedLocation:     "/lists/1",
+		},
+		{
+			name:      "Replayed",
+			key:       "key",
+			inputBody: `{"title": "test"}`,
+			mockBehavior: func(r *service_mocks.MockIdempotency) {
+				r.EXPECT().Begin(1, "key", gomock.Any()).Return(&todo.IdempotencyKey{
+					Key:             "key",
+					StatusCode:      &statusCode,
+					ResponseHeaders: todo.ResponseHeaders{"Location": "/lists/2"},
+					ResponseBody:    []byte(`{"id":2}`),
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":2}`,
+			expectedLocation:     "/lists/2",
+			expectedReplayed:     "true",
+		},
+		{
+			name:      "Handler Panics",
+			key:       "key",
+			inputBody: `{"title": "test"}`,
+			panics:    true,
+			mockBehavior: func(r *service_mocks.MockIdempotency) {
+				r.EXPECT().Begin(1, "key", gomock.Any()).Return(nil, nil)
+				r.EXPECT().Release(1, "key").Return(nil)
+			},
+		},
+		{
+			name:      "Key Reused",
+			key:       "key",
+			inputBody: `{"title": "other"}`,
+			mockBehavior: func(r *service_mocks.MockIdempotency) {
+				r.EXPECT().Begin(1, "key", gomock.Any()).Return(nil, todo.ErrIdempotencyKeyReused)
+			},
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"message":"` + todo.ErrIdempotencyKeyReused.Error() + `","code":"idempotency_key_reused"}`,
+		},
+		{
+			name:      "In Progress",
+			key:       "key",
+			inputBody: `{"title": "test"}`,
+			mockBehavior: func(r *service_mocks.MockIdempotency) {
+				r.EXPECT().Begin(1, "key", gomock.Any()).Return(nil, todo.ErrRequestInProgress)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"` + todo.ErrRequestInProgress.Error() + `","code":"request_in_progress"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockIdempotency(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{Idempotency: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/lists", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.idempotency, func(c *gin.Context) {
+				if test.panics {
+					panic("some error")
+				}
+				c.Header("Location", "/lists/1")
+				c.JSON(200, map[string]interface{}{"id": 1})
+			})
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/lists",
+				bytes.NewBufferString(test.inputBody))
+			if test.key != "" {
+				req.Header.Set(idempotencyKeyHeader, test.key)
+			}
+
+			// Make Request
+			if test.panics {
+				assert.Panics(t, func() { r.ServeHTTP(w, req) })
+				return
+			}
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+			assert.Equal(t, test.expectedLocation, w.Header().Get("Location"))
+			assert.Equal(t, test.expectedReplayed, w.Header().Get(idempotentReplayedHeader))
+		})
+	}
+}
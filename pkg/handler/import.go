@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/export"
+)
+
+const maxImportSize = 10 << 20
+
+// @Summary Import Lists
+// @Security ApiKeyAuth
+// @Tags import
+// @Description create lists from an uploaded JSON export, CSV, Markdown checklist or todo.txt file
+// @Description in a single transaction. Nothing is created if any row can't be imported.
+// @ID import-lists
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "file to import"
+// @Param format query string false "json, csv, markdown or todotxt, inferred from the file name by default"
+// @Param dry_run query bool false "only validate the file and preview the lists"
+// @Success 200 {object} todo.ImportResult
+// @Failure 422 {object} todo.ImportResult
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/import [post]
+func (h *Handler) importLists(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var input todo.ImportInput
+	if err := c.BindQuery(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	header, err := c.FormFile("file")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if input.Format == "" {
+		if input.Format, err = export.Format(header.Filename); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer file.Close()
+
+	lists, importErrors, err := export.Decode(file, input.Format)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(importErrors) > 0 {
+		status := http.StatusUnprocessableEntity
+		if input.DryRun {
+			status = http.StatusOK
+		}
+
+		preview := make([]todo.ImportedList, len(lists))
+		for i, list := range lists {
+			preview[i] = todo.ImportedList{Title: list.Title, Items: len(list.Items)}
+		}
+
+		c.JSON(status, todo.ImportResult{
+			DryRun: input.DryRun,
+			Lists:  preview,
+			Errors: importErrors,
+		})
+		return
+	}
+
+	imported, err := h.services.Import.Import(userId, lists, input.DryRun)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, todo.ImportResult{
+		DryRun: input.DryRun,
+		Lists:  imported,
+		Errors: []todo.ImportError{},
+	})
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_importLists(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockImport)
+
+	tests := []struct {
+		name                 string
+		query                string
+		filename             string
+		file                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:     "Ok",
+			filename: "groceries.md",
+			file:     "# Groceries\n- [ ] Bread\n- [x] Milk\n",
+			mockBehavior: func(r *service_mocks.MockImport) {
+				r.EXPECT().Import(1, []todo.ListExport{
+					{
+						TodoList: todo.TodoList{Title: "Groceries"},
+						Items: []todo.TodoItem{
+							{Id: 1, Title: "Bread"},
+							{Id: 2, Title: "Milk", Done: true},
+						},
+					},
+				}, false).Return([]todo.ImportedList{{Id: 3, Title: "Groceries", Items: 2}}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"dry_run":false,"lists":[{"id":3,"title":"Groceries","items":2}],"errors":[]}`,
+		},
+		{
+			name:     "Dry Run",
+			query:    "?format=todotxt&dry_run=true",
+			filename: "todo",
+			file:     "Bread +Groceries\n",
+			mockBehavior: func(r *service_mocks.MockImport) {
+				r.EXPECT().Import(1, []todo.ListExport{
+					{
+						TodoList: todo.TodoList{Title: "Groceries"},
+						Items:    []todo.TodoItem{{Id: 1, Title: "Bread"}},
+					},
+				}, true).Return([]todo.ImportedList{{Title: "Groceries", Items: 1}}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"dry_run":true,"lists":[{"title":"Groceries","items":1}],"errors":[]}`,
+		},
+		{
+			name:                 "Invalid Rows",
+			filename:             "groceries.csv",
+			file:                 "title,done\nBread,false\n,true\n",
+			mockBehavior:         func(r *service_mocks.MockImport) {},
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"dry_run":false,"lists":[{"title":"Imported","items":2}],"errors":[{"row":3,"message":"title is required"}]}`,
+		},
+		{
+			name:                 "Unknown Format",
+			filename:             "groceries.xml",
+			file:                 "<list/>",
+			mockBehavior:         func(r *service_mocks.MockImport) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"can't infer the format of \"groceries.xml\""}`,
+		},
+		{
+			name:                 "Invalid File",
+			filename:             "groceries.json",
+			file:                 "[",
+			mockBehavior:         func(r *service_mocks.MockImport) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid json: unexpected end of JSON input"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockImport(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{Import: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/import", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.importLists)
+
+			// Create Request
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			part, err := form.CreateFormFile("file", test.filename)
+			assert.NoError(t, err)
+			_, err = part.Write([]byte(test.file))
+			assert.NoError(t, err)
+			assert.NoError(t, form.Close())
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/import"+test.query, &body)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/zhashkevych/todo-app"
 	"net/http"
@@ -32,9 +33,35 @@ func (h *Handler) createItem(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"id": id,
-	})
+	respondCreated(c, id, "items", h.itemLoader(userId, id))
+}
+
+func (h *Handler) createChildItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	parentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid item id param")
+		return
+	}
+
+	var input todo.TodoItem
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.services.TodoItem.CreateChild(userId, parentId, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCreated(c, id, "items", h.itemLoader(userId, id))
 }
 
 func (h *Handler) getAllItems(c *gin.Context) {
@@ -50,13 +77,103 @@ func (h *Handler) getAllItems(c *gin.Context) {
 		return
 	}
 
-	items, err := h.services.TodoItem.GetAll(userId, listId)
+	var filter todo.ItemFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter.Page = collectionPage(c, filter.Page)
+
+	items, total, err := h.services.TodoItem.GetAll(userId, listId, filter)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, items)
+	respondPage(c, items, items, filter.Limit, filter.Offset, &total)
+}
+
+type bulkItemsResponse struct {
+	Data []todo.ItemOperationResult `json:"data"`
+}
+
+func (h *Handler) bulkItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var input todo.BulkItemsInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.services.TodoItem.Bulk(userId, input)
+	if err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bulkItemsResponse{
+		Data: results,
+	})
+}
+
+func (h *Handler) getUserItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var filter todo.ItemFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter.Page = collectionPage(c, filter.Page)
+
+	items, total, err := h.services.TodoItem.GetAllByUser(userId, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondPage(c, items, items, filter.Limit, filter.Offset, &total)
+}
+
+func (h *Handler) getChildItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid item id param")
+		return
+	}
+
+	var filter todo.ItemFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter.Page = collectionPage(c, filter.Page)
+
+	items, total, err := h.services.TodoItem.GetChildren(userId, itemId, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondPage(c, items, items, filter.Limit, filter.Offset, &total)
 }
 
 func (h *Handler) getItemById(c *gin.Context) {
@@ -74,7 +191,11 @@ func (h *Handler) getItemById(c *gin.Context) {
 
 	item, err := h.services.TodoItem.GetById(userId, itemId)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	if notModified(c, itemETag(item)) {
 		return
 	}
 
@@ -100,12 +221,55 @@ func (h *Handler) updateItem(c *gin.Context) {
 		return
 	}
 
-	if err := h.services.TodoItem.Update(userId, id, input); err != nil {
+	version, err := getIfMatch(c)
+	if err != nil {
+		newCodedErrorResponse(c, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	if err := h.services.TodoItem.Update(userId, id, input, version); err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	respondUpdated(c, h.itemLoader(userId, id))
+}
+
+func (h *Handler) patchItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, statusResponse{"ok"})
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	patch, err := getPatch(c)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errUnsupportedPatch) {
+			status = http.StatusUnsupportedMediaType
+		}
+		newErrorResponse(c, status, err.Error())
+		return
+	}
+
+	version, err := getIfMatch(c)
+	if err != nil {
+		newCodedErrorResponse(c, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	if err := h.services.TodoItem.Patch(userId, id, patch, version); err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	respondUpdated(c, h.itemLoader(userId, id))
 }
 
 func (h *Handler) deleteItem(c *gin.Context) {
@@ -121,11 +285,135 @@ func (h *Handler) deleteItem(c *gin.Context) {
 		return
 	}
 
-	err = h.services.TodoItem.Delete(userId, itemId)
+	version, err := getIfMatch(c)
 	if err != nil {
+		newCodedErrorResponse(c, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	err = h.services.TodoItem.Delete(userId, itemId, version)
+	if err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	respondDeleted(c)
+}
+
+func (h *Handler) moveItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	var input todo.MoveItemInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.TodoItem.Move(userId, id, input); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondUpdated(c, h.itemLoader(userId, id))
+}
+
+func (h *Handler) copyItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	var input todo.CopyItemInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	copyId, err := h.services.TodoItem.Copy(userId, id, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCreated(c, copyId, "items", h.itemLoader(userId, copyId))
+}
+
+func (h *Handler) archiveItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	if err := h.services.TodoItem.Archive(userId, id); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondUpdated(c, h.itemLoader(userId, id))
+}
+
+func (h *Handler) unarchiveItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	if err := h.services.TodoItem.Unarchive(userId, id); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondUpdated(c, h.itemLoader(userId, id))
+}
+
+func (h *Handler) restoreItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	if err := h.services.TodoItem.Restore(userId, id); err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, statusResponse{"ok"})
+	respondUpdated(c, h.itemLoader(userId, id))
 }
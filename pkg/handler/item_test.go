@@ -0,0 +1,422 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_getItemById(t *testing.T) {
+	createdAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	authorId := 1
+
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem, itemId int)
+
+	tests := []struct {
+		name                 string
+		itemId               string
+		ifNoneMatch          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Ok",
+			itemId: "1",
+			mockBehavior: func(r *service_mocks.MockTodoItem, itemId int) {
+				r.EXPECT().GetById(1, itemId).Return(todo.TodoItem{
+					Id:        itemId,
+					Title:     "title",
+					ListId:    2,
+					Position:  "i",
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+					CreatedBy: &authorId,
+					UpdatedBy: &authorId,
+					Version:   3,
+					Progress:  &todo.ItemProgress{Total: 5, Done: 3},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"id":1,"title":"title","description":"","done":false,"list_id":2,"parent_id":null,"position":"i","archived":false,` +
+				`"created_at":"2021-03-01T10:00:00Z","updated_at":"2021-03-01T10:00:00Z","created_by":1,"updated_by":1,"completed_at":null,"due_date":null,"version":3,"progress":{"total":5,"done":3}}`,
+		},
+		{
+			name:        "Not Modified",
+			itemId:      "1",
+			ifNoneMatch: `"2", "3"`,
+			mockBehavior: func(r *service_mocks.MockTodoItem, itemId int) {
+				r.EXPECT().GetById(1, itemId).Return(todo.TodoItem{Id: itemId, Title: "title", Version: 3}, nil)
+			},
+			expectedStatusCode: 304,
+		},
+		{
+			name:        "Not Modified With Progress",
+			itemId:      "1",
+			ifNoneMatch: `"3.5.3"`,
+			mockBehavior: func(r *service_mocks.MockTodoItem, itemId int) {
+				r.EXPECT().GetById(1, itemId).Return(todo.TodoItem{Id: itemId, Title: "title", Version: 3,
+					Progress: &todo.ItemProgress{Total: 5, Done: 3}}, nil)
+			},
+			expectedStatusCode: 304,
+		},
+		{
+			name:        "Progress Changed",
+			itemId:      "1",
+			ifNoneMatch: `"3.5.2"`,
+			mockBehavior: func(r *service_mocks.MockTodoItem, itemId int) {
+				r.EXPECT().GetById(1, itemId).Return(todo.TodoItem{Id: itemId, Title: "title", Version: 3,
+					Progress: &todo.ItemProgress{Total: 5, Done: 3}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"id":1,"title":"title","description":"","done":false,"list_id":0,"parent_id":null,"position":"","archived":false,` +
+				`"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","created_by":null,"updated_by":null,"completed_at":null,"due_date":null,"version":3,"progress":{"total":5,"done":3}}`,
+		},
+		{
+			name:                 "Invalid Id",
+			itemId:               "abc",
+			mockBehavior:         func(r *service_mocks.MockTodoItem, itemId int) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid list id param"}`,
+		},
+		{
+			name:   "Service Error",
+			itemId: "1",
+			mockBehavior: func(r *service_mocks.MockTodoItem, itemId int) {
+				r.EXPECT().GetById(1, itemId).Return(todo.TodoItem{}, errors.New("sql: no rows in result set"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"sql: no rows in result set"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(repo, 1)
+
+			services := &service.Service{TodoItem: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/items/:id", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.getItemById)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/items/"+test.itemId, nil)
+			if test.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", test.ifNoneMatch)
+			}
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateItem(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem, input todo.UpdateItemInput)
+
+	version := 3
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		input                todo.UpdateItemInput
+		ifMatch              string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"done": true}`,
+			input:     todo.UpdateItemInput{Done: boolPointer(true)},
+			mockBehavior: func(r *service_mocks.MockTodoItem, input todo.UpdateItemInput) {
+				r.EXPECT().Update(1, 1, input, nil).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:      "Ok_IfMatch",
+			inputBody: `{"done": true}`,
+			input:     todo.UpdateItemInput{Done: boolPointer(true)},
+			ifMatch:   `"3"`,
+			mockBehavior: func(r *service_mocks.MockTodoItem, input todo.UpdateItemInput) {
+				r.EXPECT().Update(1, 1, input, &version).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:      "Ok_IfMatch_Progress",
+			inputBody: `{"done": true}`,
+			input:     todo.UpdateItemInput{Done: boolPointer(true)},
+			ifMatch:   `"3.5.2"`,
+			mockBehavior: func(r *service_mocks.MockTodoItem, input todo.UpdateItemInput) {
+				r.EXPECT().Update(1, 1, input, &version).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:      "Version Mismatch",
+			inputBody: `{"done": true}`,
+			input:     todo.UpdateItemInput{Done: boolPointer(true)},
+			ifMatch:   `"3"`,
+			mockBehavior: func(r *service_mocks.MockTodoItem, input todo.UpdateItemInput) {
+				r.EXPECT().Update(1, 1, input, &version).Return(todo.ErrVersionMismatch)
+			},
+			expectedStatusCode:   412,
+			expectedResponseBody: `{"message":"resource has been modified","code":"version_mismatch"}`,
+		},
+		{
+			name:                 "Weak If-Match",
+			inputBody:            `{"done": true}`,
+			ifMatch:              `W/"3"`,
+			mockBehavior:         func(r *service_mocks.MockTodoItem, input todo.UpdateItemInput) {},
+			expectedStatusCode:   412,
+			expectedResponseBody: `{"message":"resource has been modified","code":"version_mismatch"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(repo, test.input)
+
+			services := &service.Service{TodoItem: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.PUT("/items/:id", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.updateItem)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/items/1", bytes.NewBufferString(test.inputBody))
+			if test.ifMatch != "" {
+				req.Header.Set("If-Match", test.ifMatch)
+			}
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func boolPointer(b bool) *bool {
+	return &b
+}
+
+func TestHandler_patchItem(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem, patch todo.Patch)
+
+	tests := []struct {
+		name                 string
+		contentType          string
+		inputBody            string
+		patch                todo.Patch
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok_MergePatch",
+			contentType: "application/merge-patch+json",
+			inputBody:   `{"description": null}`,
+			patch:       todo.Patch{Type: todo.MergePatch, Document: []byte(`{"description": null}`)},
+			mockBehavior: func(r *service_mocks.MockTodoItem, patch todo.Patch) {
+				r.EXPECT().Patch(1, 1, patch, nil).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:        "Ok_JSONPatch",
+			contentType: "application/json-patch+json",
+			inputBody:   `[{"op": "replace", "path": "/done", "value": true}]`,
+			patch:       todo.Patch{Type: todo.JSONPatch, Document: []byte(`[{"op": "replace", "path": "/done", "value": true}]`)},
+			mockBehavior: func(r *service_mocks.MockTodoItem, patch todo.Patch) {
+				r.EXPECT().Patch(1, 1, patch, nil).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Unsupported Content Type",
+			contentType:          "text/plain",
+			inputBody:            `title=new`,
+			mockBehavior:         func(r *service_mocks.MockTodoItem, patch todo.Patch) {},
+			expectedStatusCode:   415,
+			expectedResponseBody: `{"message":"unsupported patch content type"}`,
+		},
+		{
+			name:        "Unknown Field",
+			contentType: "application/merge-patch+json",
+			inputBody:   `{"priority": 1}`,
+			patch:       todo.Patch{Type: todo.MergePatch, Document: []byte(`{"priority": 1}`)},
+			mockBehavior: func(r *service_mocks.MockTodoItem, patch todo.Patch) {
+				r.EXPECT().Patch(1, 1, patch, nil).Return(fmt.Errorf("%w: unknown field %q", todo.ErrInvalidPatch, "priority"))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid patch: unknown field \"priority\"","code":"invalid_patch"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(repo, test.patch)
+
+			services := &service.Service{TodoItem: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.PATCH("/items/:id", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.patchItem)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/items/1", bytes.NewBufferString(test.inputBody))
+			req.Header.Set("Content-Type", test.contentType)
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_bulkItems(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem, input todo.BulkItemsInput)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		input                todo.BulkItemsInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"operations": [{"op": "update", "id": 1, "update": {"done": true}}, {"op": "delete", "id": 2}]}`,
+			input: todo.BulkItemsInput{
+				Operations: []todo.ItemOperation{
+					{Op: "update", Id: 1, Update: &todo.UpdateItemInput{Done: boolPointer(true)}},
+					{Op: "delete", Id: 2},
+				},
+			},
+			mockBehavior: func(r *service_mocks.MockTodoItem, input todo.BulkItemsInput) {
+				r.EXPECT().Bulk(1, input).Return([]todo.ItemOperationResult{
+					{Status: "ok", Id: 1},
+					{Status: "failed", Id: 2, Error: "sql: no rows in result set"},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"status":"ok","id":1},{"status":"failed","id":2,"error":"sql: no rows in result set"}]}`,
+		},
+		{
+			name:                 "Unknown Operation",
+			inputBody:            `{"operations": [{"op": "archive", "id": 1}]}`,
+			mockBehavior:         func(r *service_mocks.MockTodoItem, input todo.BulkItemsInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"Key: 'BulkItemsInput.Operations[0].Op' Error:Field validation for 'Op' failed on the 'oneof' tag"}`,
+		},
+		{
+			name:                 "No Operations",
+			inputBody:            `{"atomic": true}`,
+			mockBehavior:         func(r *service_mocks.MockTodoItem, input todo.BulkItemsInput) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"Key: 'BulkItemsInput.Operations' Error:Field validation for 'Operations' failed on the 'required' tag"}`,
+		},
+		{
+			name:      "Invalid Operation",
+			inputBody: `{"operations": [{"op": "update", "id": 1}]}`,
+			input: todo.BulkItemsInput{
+				Operations: []todo.ItemOperation{{Op: "update", Id: 1}},
+			},
+			mockBehavior: func(r *service_mocks.MockTodoItem, input todo.BulkItemsInput) {
+				r.EXPECT().Bulk(1, input).Return(nil, fmt.Errorf("%w: operation 0: update requires id and update", todo.ErrInvalidOperation))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid operation: operation 0: update requires id and update","code":"invalid_operation"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(repo, test.input)
+
+			services := &service.Service{TodoItem: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/bulk/items", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.bulkItems)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/bulk/items", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
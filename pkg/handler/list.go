@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -40,9 +41,7 @@ func (h *Handler) createList(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"id": id,
-	})
+	respondCreated(c, id, "lists", h.listLoader(userId, id))
 }
 
 type getAllListsResponse struct {
@@ -56,6 +55,10 @@ type getAllListsResponse struct {
 // @ID get-all-lists
 // @Accept  json
 // @Produce  json
+// @Param include_archived query bool false "include archived lists"
+// @Param sort query string false "sort by created_at or updated_at, prefix with - for descending order"
+// @Param limit query int false "page size since v2, 50 by default"
+// @Param offset query int false "number of lists to skip since v2"
 // @Success 200 {object} getAllListsResponse
 // @Failure 400,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
@@ -68,15 +71,21 @@ func (h *Handler) getAllLists(c *gin.Context) {
 		return
 	}
 
-	lists, err := h.services.TodoList.GetAll(userId)
+	var filter todo.ListFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter.Page = collectionPage(c, filter.Page)
+
+	lists, total, err := h.services.TodoList.GetAll(userId, filter)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, getAllListsResponse{
-		Data: lists,
-	})
+	respondPage(c, getAllListsResponse{Data: lists}, lists, filter.Limit, filter.Offset, &total)
 }
 
 // @Summary Get List By Id
@@ -86,7 +95,10 @@ func (h *Handler) getAllLists(c *gin.Context) {
 // @ID get-list-by-id
 // @Accept  json
 // @Produce  json
+// @Param If-None-Match header string false "ETag of the cached list"
 // @Success 200 {object} todo.ListItem
+// @Header 200 {string} ETag "list version"
+// @Success 304
 // @Failure 400,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
@@ -106,7 +118,11 @@ func (h *Handler) getListById(c *gin.Context) {
 
 	list, err := h.services.TodoList.GetById(userId, id)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	if notModified(c, formatETag(list.Version)) {
 		return
 	}
 
@@ -132,12 +148,55 @@ func (h *Handler) updateList(c *gin.Context) {
 		return
 	}
 
-	if err := h.services.TodoList.Update(userId, id, input); err != nil {
+	version, err := getIfMatch(c)
+	if err != nil {
+		newCodedErrorResponse(c, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	if err := h.services.TodoList.Update(userId, id, input, version); err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	respondUpdated(c, h.listLoader(userId, id))
+}
+
+func (h *Handler) patchList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, statusResponse{"ok"})
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	patch, err := getPatch(c)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errUnsupportedPatch) {
+			status = http.StatusUnsupportedMediaType
+		}
+		newErrorResponse(c, status, err.Error())
+		return
+	}
+
+	version, err := getIfMatch(c)
+	if err != nil {
+		newCodedErrorResponse(c, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	if err := h.services.TodoList.Patch(userId, id, patch, version); err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	respondUpdated(c, h.listLoader(userId, id))
 }
 
 func (h *Handler) deleteList(c *gin.Context) {
@@ -153,13 +212,169 @@ func (h *Handler) deleteList(c *gin.Context) {
 		return
 	}
 
-	err = h.services.TodoList.Delete(userId, id)
+	version, err := getIfMatch(c)
+	if err != nil {
+		newCodedErrorResponse(c, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	err = h.services.TodoList.Delete(userId, id, version)
+	if err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	respondDeleted(c)
+}
+
+func (h *Handler) moveList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	var input todo.MoveListInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.TodoList.Move(userId, id, input); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondUpdated(c, h.listLoader(userId, id))
+}
+
+func (h *Handler) duplicateList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	// the body is optional, all of its fields have defaults
+	var input todo.DuplicateListInput
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&input); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	copyId, err := h.services.TodoList.Duplicate(userId, id, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCreated(c, copyId, "lists", h.listLoader(userId, copyId))
+}
+
+func (h *Handler) instantiateList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	// the body is optional, all of its fields have defaults
+	var input todo.InstantiateListInput
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&input); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	listId, err := h.services.TodoList.Instantiate(userId, id, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCreated(c, listId, "lists", h.listLoader(userId, listId))
+}
+
+func (h *Handler) archiveList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	if err := h.services.TodoList.Archive(userId, id); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondUpdated(c, h.listLoader(userId, id))
+}
+
+func (h *Handler) unarchiveList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	if err := h.services.TodoList.Unarchive(userId, id); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondUpdated(c, h.listLoader(userId, id))
+}
+
+func (h *Handler) restoreList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	if err := h.services.TodoList.Restore(userId, id); err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, statusResponse{
-		Status: "ok",
-	})
+	respondUpdated(c, h.listLoader(userId, id))
 }
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_duplicateList(t *testing.T) {
+	title := "copy"
+
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoList)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"title":"copy","reset_done":true}`,
+			mockBehavior: func(r *service_mocks.MockTodoList) {
+				r.EXPECT().Duplicate(1, 1, todo.DuplicateListInput{Title: &title, ResetDone: true}).Return(2, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":2}`,
+		},
+		{
+			name: "Empty Body",
+			mockBehavior: func(r *service_mocks.MockTodoList) {
+				r.EXPECT().Duplicate(1, 1, todo.DuplicateListInput{}).Return(2, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":2}`,
+		},
+		{
+			name:                 "Invalid Body",
+			inputBody:            `{"title":`,
+			mockBehavior:         func(r *service_mocks.MockTodoList) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"unexpected EOF"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockTodoList(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{TodoList: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/lists/:id/duplicate", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.duplicateList)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/lists/1/duplicate", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
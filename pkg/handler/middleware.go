@@ -39,6 +39,18 @@ func (h *Handler) userIdentity(c *gin.Context) {
 	c.Set(userCtx, userId)
 }
 
+// calendarIdentity authenticates calendar clients, which can't send bearer tokens,
+// by the calendar token in the path.
+func (h *Handler) calendarIdentity(c *gin.Context) {
+	userId, err := h.services.Calendar.GetUserId(c.Param("token"))
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "invalid calendar token")
+		return
+	}
+
+	c.Set(userCtx, userId)
+}
+
 func getUserId(c *gin.Context) (int, error) {
 	id, ok := c.Get(userCtx)
 	if !ok {
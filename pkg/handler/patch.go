@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+var errUnsupportedPatch = errors.New("unsupported patch content type")
+
+// getPatch reads the patch document from the request body. Plain JSON bodies
+// are treated as merge patches.
+func getPatch(c *gin.Context) (todo.Patch, error) {
+	var patch todo.Patch
+
+	switch c.ContentType() {
+	case mergePatchContentType, gin.MIMEJSON:
+		patch.Type = todo.MergePatch
+	case jsonPatchContentType:
+		patch.Type = todo.JSONPatch
+	default:
+		return patch, errUnsupportedPatch
+	}
+
+	document, err := c.GetRawData()
+	if err != nil {
+		return patch, err
+	}
+	patch.Document = document
+
+	return patch, nil
+}
@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"database/sql"
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app"
 )
 
 type errorResponse struct {
 	Message string `json:"message"`
+	// Code identifies the errors of the todo package, see todo.ErrorCode.
+	Code string `json:"code,omitempty"`
 }
 
 type statusResponse struct {
@@ -15,5 +22,30 @@ type statusResponse struct {
 
 func newErrorResponse(c *gin.Context, statusCode int, message string) {
 	logrus.Error(message)
-	c.AbortWithStatusJSON(statusCode, errorResponse{message})
-}
\ No newline at end of file
+	c.AbortWithStatusJSON(statusCode, errorResponse{Message: message})
+}
+
+// newCodedErrorResponse responds with the message of the error and the code of the error of the todo package it wraps.
+func newCodedErrorResponse(c *gin.Context, statusCode int, err error) {
+	logrus.Error(err.Error())
+	c.AbortWithStatusJSON(statusCode, errorResponse{Message: err.Error(), Code: todo.ErrorCode(err)})
+}
+
+// errorStatus returns the response status code for an error returned by the services.
+// Missing resources are reported with 404 since v2, v1 keeps responding with 500.
+func errorStatus(c *gin.Context, err error) int {
+	switch {
+	case errors.Is(err, todo.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, todo.ErrInvalidPatch), errors.Is(err, todo.ErrInvalidOperation):
+		return http.StatusBadRequest
+	case errors.Is(err, todo.ErrNothingToUndo):
+		return http.StatusNotFound
+	case errors.Is(err, sql.ErrNoRows) && !isV1(c):
+		return http.StatusNotFound
+	case errors.Is(err, todo.ErrUndoConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	lastEventIdHeader = "Last-Event-ID"
+
+	// streamDuration keeps a stream shorter than the server's write timeout. Clients reconnect
+	// after streamRetry and receive the activity they've missed by the Last-Event-ID header.
+	streamDuration = 8 * time.Second
+	streamRetry    = 500 * time.Millisecond
+)
+
+// @Summary Stream Activity
+// @Security ApiKeyAuth
+// @Tags activity
+// @Description stream changes of all lists of the user as server-sent events named after the action
+// @ID stream-activity
+// @Produce  text/event-stream
+// @Param Last-Event-ID header int false "id of the last received event"
+// @Success 200 {object} todo.Activity
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/stream [get]
+func (h *Handler) streamActivity(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// subscribe before reading the activity, so nothing recorded in between is missed
+	wake, unsubscribe := h.services.Stream.Subscribe(userId)
+	defer unsubscribe()
+
+	lastId, err := h.getLastEventId(c, userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+	c.Writer.Flush()
+
+	timeout := time.NewTimer(streamDuration)
+	defer timeout.Stop()
+
+	for {
+		if lastId, err = h.sendActivity(c, userId, lastId); err != nil {
+			logrus.Errorf("error occured while streaming activity: %s", err.Error())
+			return
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-timeout.C:
+			return
+		case <-wake:
+		}
+	}
+}
+
+// getLastEventId returns the id of the last event received by the client
+// or the id of the latest activity for a new stream.
+func (h *Handler) getLastEventId(c *gin.Context, userId int) (int, error) {
+	lastEventId := c.GetHeader(lastEventIdHeader)
+	if lastEventId == "" {
+		lastEventId = c.Query("last_event_id")
+	}
+
+	if lastEventId == "" {
+		return h.services.Stream.GetLastId(userId)
+	}
+
+	id, err := strconv.Atoi(lastEventId)
+	if err != nil {
+		return 0, fmt.Errorf("invalid last event id %q", lastEventId)
+	}
+
+	return id, nil
+}
+
+// sendActivity writes the activity recorded after lastId as events
+// and returns the id of the last one sent.
+func (h *Handler) sendActivity(c *gin.Context, userId, lastId int) (int, error) {
+	for {
+		activity, err := h.services.Stream.GetSince(userId, lastId)
+		if err != nil || len(activity) == 0 {
+			return lastId, err
+		}
+
+		for _, a := range activity {
+			data, err := json.Marshal(a)
+			if err != nil {
+				return lastId, err
+			}
+
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", a.Id, a.Action, data); err != nil {
+				return lastId, err
+			}
+			lastId = a.Id
+		}
+		c.Writer.Flush()
+	}
+}
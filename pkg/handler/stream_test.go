@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_streamActivity(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockStream, cancel context.CancelFunc)
+
+	actorId, version := 1, 2
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		lastEventId          string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			lastEventId: "5",
+			mockBehavior: func(r *service_mocks.MockStream, cancel context.CancelFunc) {
+				r.EXPECT().Subscribe(1).Return(make(chan struct{}), func() {})
+				r.EXPECT().GetSince(1, 5).Return([]todo.Activity{
+					{Id: 6, ActorId: &actorId, ListId: 1, Action: todo.ActivityUpdate, Version: &version, CreatedAt: createdAt},
+				}, nil)
+				r.EXPECT().GetSince(1, 6).DoAndReturn(func(userId, afterId int) ([]todo.Activity, error) {
+					// the client disconnects
+					cancel()
+					return nil, nil
+				})
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: "retry: 500\n\n" +
+				"id: 6\nevent: update\ndata: {\"id\":6,\"actor_id\":1,\"list_id\":1,\"item_id\":null,\"action\":\"update\"," +
+				"\"version\":2,\"created_at\":\"2021-01-01T00:00:00Z\"}\n\n",
+		},
+		{
+			name: "New Stream",
+			mockBehavior: func(r *service_mocks.MockStream, cancel context.CancelFunc) {
+				r.EXPECT().Subscribe(1).Return(make(chan struct{}), func() {})
+				r.EXPECT().GetLastId(1).Return(7, nil)
+				r.EXPECT().GetSince(1, 7).DoAndReturn(func(userId, afterId int) ([]todo.Activity, error) {
+					cancel()
+					return nil, nil
+				})
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: "retry: 500\n\n",
+		},
+		{
+			name:        "Invalid Last Event Id",
+			lastEventId: "last",
+			mockBehavior: func(r *service_mocks.MockStream, cancel context.CancelFunc) {
+				r.EXPECT().Subscribe(1).Return(make(chan struct{}), func() {})
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid last event id \"last\""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			repo := service_mocks.NewMockStream(c)
+			test.mockBehavior(repo, cancel)
+
+			services := &service.Service{Stream: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.GET("/stream", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.streamActivity)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/stream", nil).WithContext(ctx)
+			if test.lastEventId != "" {
+				req.Header.Set(lastEventIdHeader, test.lastEventId)
+			}
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+)
+
+// @Summary Create tag
+// @Security ApiKeyAuth
+// @Tags tags
+// @Description create tag
+// @ID create-tag
+// @Accept  json
+// @Produce  json
+// @Param input body todo.Tag true "tag info"
+// @Success 200 {integer} integer 1
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags [post]
+func (h *Handler) createTag(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var input todo.Tag
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.services.Tag.Create(userId, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCreated(c, id, "tags", h.tagLoader(userId, id))
+}
+
+type getAllTagsResponse struct {
+	Data []todo.Tag `json:"data"`
+}
+
+// @Summary Get All Tags
+// @Security ApiKeyAuth
+// @Tags tags
+// @Description get all tags
+// @ID get-all-tags
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getAllTagsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags [get]
+func (h *Handler) getAllTags(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	tags, err := h.services.Tag.GetAll(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCollection(c, getAllTagsResponse{Data: tags}, tags)
+}
+
+// @Summary Get Tag By Id
+// @Security ApiKeyAuth
+// @Tags tags
+// @Description get tag by id
+// @ID get-tag-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path int true "tag id"
+// @Success 200 {object} todo.Tag
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags/{id} [get]
+func (h *Handler) getTagById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	tag, err := h.services.Tag.GetById(userId, id)
+	if err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// @Summary Update Tag
+// @Security ApiKeyAuth
+// @Tags tags
+// @Description update tag
+// @ID update-tag
+// @Accept  json
+// @Produce  json
+// @Param id path int true "tag id"
+// @Param input body todo.UpdateTagInput true "tag info"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags/{id} [put]
+func (h *Handler) updateTag(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	var input todo.UpdateTagInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.Tag.Update(userId, id, input); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondUpdated(c, h.tagLoader(userId, id))
+}
+
+// @Summary Delete Tag
+// @Security ApiKeyAuth
+// @Tags tags
+// @Description delete tag
+// @ID delete-tag
+// @Accept  json
+// @Produce  json
+// @Param id path int true "tag id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags/{id} [delete]
+func (h *Handler) deleteTag(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	if err := h.services.Tag.Delete(userId, id); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondDeleted(c)
+}
+
+// @Summary Get Item Tags
+// @Security ApiKeyAuth
+// @Tags tags
+// @Description get tags of an item
+// @ID get-item-tags
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {object} getAllTagsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/items/{id}/tags [get]
+func (h *Handler) getItemTags(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid item id param")
+		return
+	}
+
+	tags, err := h.services.Tag.GetByItemId(userId, itemId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCollection(c, getAllTagsResponse{Data: tags}, tags)
+}
+
+// @Summary Add Item Tag
+// @Security ApiKeyAuth
+// @Tags tags
+// @Description add tag to an item
+// @ID add-item-tag
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param tag_id path int true "tag id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/items/{id}/tags/{tag_id} [post]
+func (h *Handler) addItemTag(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid item id param")
+		return
+	}
+
+	tagId, err := strconv.Atoi(c.Param("tag_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid tag id param")
+		return
+	}
+
+	if err := h.services.Tag.AddToItem(userId, itemId, tagId); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondDeleted(c)
+}
+
+// @Summary Remove Item Tag
+// @Security ApiKeyAuth
+// @Tags tags
+// @Description remove tag from an item
+// @ID remove-item-tag
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param tag_id path int true "tag id"
+// @Success 200 {object} statusResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/items/{id}/tags/{tag_id} [delete]
+func (h *Handler) removeItemTag(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid item id param")
+		return
+	}
+
+	tagId, err := strconv.Atoi(c.Param("tag_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid tag id param")
+		return
+	}
+
+	if err := h.services.Tag.RemoveFromItem(userId, itemId, tagId); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondDeleted(c)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createTag(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTag, tag todo.Tag)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		inputTag             todo.Tag
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"name": "urgent"}`,
+			inputTag:  todo.Tag{Name: "urgent"},
+			mockBehavior: func(r *service_mocks.MockTag, tag todo.Tag) {
+				r.EXPECT().Create(1, tag).Return(1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:                 "Empty Name",
+			inputBody:            `{}`,
+			mockBehavior:         func(r *service_mocks.MockTag, tag todo.Tag) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"Key: 'Tag.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`,
+		},
+		{
+			name:      "Service Error",
+			inputBody: `{"name": "urgent"}`,
+			inputTag:  todo.Tag{Name: "urgent"},
+			mockBehavior: func(r *service_mocks.MockTag, tag todo.Tag) {
+				r.EXPECT().Create(1, tag).Return(0, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockTag(c)
+			test.mockBehavior(repo, test.inputTag)
+
+			services := &service.Service{Tag: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/tags", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.createTag)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tags",
+				bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+)
+
+type undoResponse struct {
+	Data []todo.Activity `json:"data"`
+}
+
+// @Summary Undo
+// @Security ApiKeyAuth
+// @Tags activity
+// @Description undo the latest operation of the user or the operation of the given activity
+// @ID undo
+// @Accept  json
+// @Produce  json
+// @Param input body todo.UndoInput false "operation to undo"
+// @Success 200 {object} undoResponse
+// @Failure 400,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/undo [post]
+func (h *Handler) undo(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// the body is optional, the latest operation is undone without it
+	var input todo.UndoInput
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&input); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	undone, err := h.services.Undo.Undo(userId, input)
+	if err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, undoResponse{
+		Data: undone,
+	})
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_undo(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockUndo, input todo.UndoInput)
+
+	actorId, itemId, version, activityId := 1, 2, 3, 4
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	undoneAt := createdAt.Add(time.Minute)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		input                todo.UndoInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *service_mocks.MockUndo, input todo.UndoInput) {
+				r.EXPECT().Undo(1, input).Return([]todo.Activity{
+					{
+						Id:        activityId,
+						ActorId:   &actorId,
+						ListId:    1,
+						ItemId:    &itemId,
+						Action:    todo.ActivityDelete,
+						Version:   &version,
+						UndoneAt:  &undoneAt,
+						CreatedAt: createdAt,
+					},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"data":[{"id":4,"actor_id":1,"list_id":1,"item_id":2,"action":"delete","version":3,` +
+				`"undone_at":"2021-01-01T00:01:00Z","created_at":"2021-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name:      "Conflict",
+			inputBody: `{"activity_id": 4}`,
+			input:     todo.UndoInput{ActivityId: &activityId},
+			mockBehavior: func(r *service_mocks.MockUndo, input todo.UndoInput) {
+				r.EXPECT().Undo(1, input).Return(nil, todo.ErrUndoConflict)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"changed since the operation, can't undo","code":"undo_conflict"}`,
+		},
+		{
+			name: "Nothing To Undo",
+			mockBehavior: func(r *service_mocks.MockUndo, input todo.UndoInput) {
+				r.EXPECT().Undo(1, input).Return(nil, todo.ErrNothingToUndo)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"message":"nothing to undo","code":"nothing_to_undo"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockUndo(c)
+			test.mockBehavior(repo, test.input)
+
+			services := &service.Service{Undo: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/undo", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.undo)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/undo", bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
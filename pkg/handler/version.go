@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	apiVersionCtx = "apiVersion"
+
+	deprecationHeader = "Deprecation"
+	sunsetHeader      = "Sunset"
+	linkHeader        = "Link"
+
+	v1Prefix = "/api"
+	v2Prefix = "/api/v2"
+)
+
+// Deprecation announces when a version of the API was deprecated and when it stops being served.
+type Deprecation struct {
+	Date   time.Time
+	Sunset time.Time
+}
+
+func apiVersion(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiVersionCtx, version)
+	}
+}
+
+// deprecated sets the Deprecation (RFC 9745) and Sunset (RFC 8594) headers of the responses
+// of v1 and links the same resource of v2 as the successor.
+func deprecated(deprecation Deprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(deprecationHeader, fmt.Sprintf("@%d", deprecation.Date.Unix()))
+		c.Header(sunsetHeader, deprecation.Sunset.UTC().Format(http.TimeFormat))
+
+		successor := v2Prefix + strings.TrimPrefix(c.Request.URL.Path, v1Prefix)
+		c.Header(linkHeader, fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+	}
+}
+
+// getAPIVersion returns the version of the API the request was made to,
+// handlers registered outside of the versioned routes respond as v1.
+func getAPIVersion(c *gin.Context) int {
+	version, ok := c.Get(apiVersionCtx)
+	if !ok {
+		return 1
+	}
+
+	return version.(int)
+}
+
+func isV1(c *gin.Context) bool {
+	return getAPIVersion(c) < 2
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_apiVersions(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem)
+
+	deprecation := Deprecation{
+		Date:   time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+	items := []todo.TodoItem{{Id: 1, Title: "Bread", ListId: 1}}
+
+	tests := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedDeprecation  string
+		expectedSunset       string
+		expectedLink         string
+		expectedLocation     string
+		expectedResponseBody string
+	}{
+		{
+			name:   "V1 Array",
+			method: "GET",
+			path:   "/api/lists/1/items/",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetAll(1, 1, todo.ItemFilter{}).Return(items, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedDeprecation:  "@1792368000",
+			expectedSunset:       "Fri, 30 Apr 2027 00:00:00 GMT",
+			expectedLink:         `</api/v2/lists/1/items/>; rel="successor-version"`,
+			expectedResponseBody: `[{"id":1,"title":"Bread","description":"","done":false,"list_id":1,"parent_id":null,"position":"","archived":false,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","created_by":null,"updated_by":null,"completed_at":null,"due_date":null,"version":0}]`,
+		},
+		{
+			name:   "V2 Array",
+			method: "GET",
+			path:   "/api/v2/lists/1/items/",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetAll(1, 1, todo.ItemFilter{Page: todo.Page{Limit: 50}}).Return(items, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"Bread","description":"","done":false,"list_id":1,"parent_id":null,"position":"","archived":false,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","created_by":null,"updated_by":null,"completed_at":null,"due_date":null,"version":0}],"pagination":{"limit":50,"offset":0,"total":1}}`,
+		},
+		{
+			name:      "V1 Create",
+			method:    "POST",
+			path:      "/api/lists/1/items/",
+			inputBody: `{"title":"Bread"}`,
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Create(1, 1, todo.TodoItem{Title: "Bread"}).Return(2, nil)
+			},
+			expectedStatusCode:   200,
+			expectedDeprecation:  "@1792368000",
+			expectedSunset:       "Fri, 30 Apr 2027 00:00:00 GMT",
+			expectedLink:         `</api/v2/lists/1/items/>; rel="successor-version"`,
+			expectedResponseBody: `{"id":2}`,
+		},
+		{
+			name:      "V2 Create",
+			method:    "POST",
+			path:      "/api/v2/lists/1/items/",
+			inputBody: `{"title":"Bread"}`,
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Create(1, 1, todo.TodoItem{Title: "Bread"}).Return(2, nil)
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{Id: 2, Title: "Bread", ListId: 1, Version: 1}, nil)
+			},
+			expectedStatusCode:   201,
+			expectedLocation:     "/api/v2/items/2",
+			expectedResponseBody: `{"id":2,"title":"Bread","description":"","done":false,"list_id":1,"parent_id":null,"position":"","archived":false,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","created_by":null,"updated_by":null,"completed_at":null,"due_date":null,"version":1}`,
+		},
+		{
+			name:   "V1 Not Found",
+			method: "GET",
+			path:   "/api/items/2",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{}, sql.ErrNoRows)
+			},
+			expectedStatusCode:   500,
+			expectedDeprecation:  "@1792368000",
+			expectedSunset:       "Fri, 30 Apr 2027 00:00:00 GMT",
+			expectedLink:         `</api/v2/items/2>; rel="successor-version"`,
+			expectedResponseBody: `{"message":"sql: no rows in result set","code":"not_found"}`,
+		},
+		{
+			name:   "V2 Not Found",
+			method: "GET",
+			path:   "/api/v2/items/2",
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().GetById(1, 2).Return(todo.TodoItem{}, sql.ErrNoRows)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"message":"sql: no rows in result set","code":"not_found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil)
+
+			repo := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(repo)
+
+			services := &service.Service{Authorization: auth, TodoItem: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := handler.InitRoutes(deprecation)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.inputBody))
+			req.Header.Set("Authorization", "Bearer token")
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedDeprecation, w.Header().Get("Deprecation"))
+			assert.Equal(t, test.expectedSunset, w.Header().Get("Sunset"))
+			assert.Equal(t, test.expectedLink, w.Header().Get("Link"))
+			assert.Equal(t, test.expectedLocation, w.Header().Get("Location"))
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zhashkevych/todo-app"
+)
+
+// @Summary Create webhook
+// @Security ApiKeyAuth
+// @Tags webhooks
+// @Description register an endpoint for events of a list or, without list_id, of all lists of the user
+// @ID create-webhook
+// @Accept  json
+// @Produce  json
+// @Param input body todo.Webhook true "webhook info"
+// @Success 200 {integer} integer 1
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks [post]
+func (h *Handler) createWebhook(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var input todo.Webhook
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.services.Webhook.Create(userId, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCreated(c, id, "webhooks", h.webhookLoader(userId, id))
+}
+
+type getAllWebhooksResponse struct {
+	Data []todo.Webhook `json:"data"`
+}
+
+// @Summary Get All Webhooks
+// @Security ApiKeyAuth
+// @Tags webhooks
+// @Description get all webhooks of the user
+// @ID get-all-webhooks
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getAllWebhooksResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks [get]
+func (h *Handler) getAllWebhooks(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	webhooks, err := h.services.Webhook.GetAll(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCollection(c, getAllWebhooksResponse{Data: webhooks}, webhooks)
+}
+
+func (h *Handler) getWebhookById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	webhook, err := h.services.Webhook.GetById(userId, id)
+	if err != nil {
+		newCodedErrorResponse(c, errorStatus(c, err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) deleteWebhook(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	if err := h.services.Webhook.Delete(userId, id); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondDeleted(c)
+}
+
+type getWebhookDeliveriesResponse struct {
+	Data []todo.WebhookDelivery `json:"data"`
+}
+
+// @Summary Get Webhook Deliveries
+// @Security ApiKeyAuth
+// @Tags webhooks
+// @Description get the latest deliveries of the webhook, newest first
+// @ID get-webhook-deliveries
+// @Accept  json
+// @Produce  json
+// @Param id path int true "webhook id"
+// @Success 200 {object} getWebhookDeliveriesResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks/{id}/deliveries [get]
+func (h *Handler) getWebhookDeliveries(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	deliveries, err := h.services.Webhook.GetDeliveries(userId, id)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondCollection(c, getWebhookDeliveriesResponse{Data: deliveries}, deliveries)
+}
+
+// @Summary Replay Webhook Delivery
+// @Security ApiKeyAuth
+// @Tags webhooks
+// @Description schedule a new delivery with the payload of the given one
+// @ID replay-webhook-delivery
+// @Accept  json
+// @Produce  json
+// @Param id path int true "webhook id"
+// @Param delivery_id path int true "delivery id"
+// @Success 200 {integer} integer 1
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks/{id}/deliveries/{delivery_id}/replay [post]
+func (h *Handler) replayWebhookDelivery(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid id param")
+		return
+	}
+
+	deliveryId, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "invalid delivery id param")
+		return
+	}
+
+	replayId, err := h.services.Webhook.Replay(userId, id, deliveryId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := http.StatusCreated
+	if isV1(c) {
+		status = http.StatusOK
+	}
+
+	c.JSON(status, map[string]interface{}{
+		"id": replayId,
+	})
+}
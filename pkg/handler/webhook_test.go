@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createWebhook(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockWebhook, webhook todo.Webhook)
+
+	listId := 2
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		inputWebhook         todo.Webhook
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"list_id": 2, "url": "https://example.com/hook", "secret": "0123456789abcdef", "events": ["item.completed"]}`,
+			inputWebhook: todo.Webhook{
+				ListId: &listId,
+				Url:    "https://example.com/hook",
+				Secret: "0123456789abcdef",
+				Events: pq.StringArray{"item.completed"},
+			},
+			mockBehavior: func(r *service_mocks.MockWebhook, webhook todo.Webhook) {
+				r.EXPECT().Create(1, webhook).Return(1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:                 "Unknown Event",
+			inputBody:            `{"url": "https://example.com/hook", "secret": "0123456789abcdef", "events": ["item.archived"]}`,
+			mockBehavior:         func(r *service_mocks.MockWebhook, webhook todo.Webhook) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"Key: 'Webhook.Events[0]' Error:Field validation for 'Events[0]' failed on the 'oneof' tag"}`,
+		},
+		{
+			name:                 "Short Secret",
+			inputBody:            `{"url": "https://example.com/hook", "secret": "secret"}`,
+			mockBehavior:         func(r *service_mocks.MockWebhook, webhook todo.Webhook) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"Key: 'Webhook.Secret' Error:Field validation for 'Secret' failed on the 'min' tag"}`,
+		},
+		{
+			name:      "Service Error",
+			inputBody: `{"url": "https://example.com/hook", "secret": "0123456789abcdef"}`,
+			inputWebhook: todo.Webhook{
+				Url:    "https://example.com/hook",
+				Secret: "0123456789abcdef",
+			},
+			mockBehavior: func(r *service_mocks.MockWebhook, webhook todo.Webhook) {
+				r.EXPECT().Create(1, webhook).Return(0, errors.New("something went wrong"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"something went wrong"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := service_mocks.NewMockWebhook(c)
+			test.mockBehavior(repo, test.inputWebhook)
+
+			services := &service.Service{Webhook: repo}
+			handler := Handler{services}
+
+			// Init Endpoint
+			r := gin.New()
+			r.POST("/webhooks", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.createWebhook)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/webhooks",
+				bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package ical renders items as VTODO components of iCalendar (RFC 5545) objects
+// and reads back the status of todos that calendar clients send.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/zhashkevych/todo-app"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	StatusCompleted   = "COMPLETED"
+	StatusNeedsAction = "NEEDS-ACTION"
+
+	productId      = "-//todo-app//todo-app//EN"
+	dateTimeLayout = "20060102T150405Z"
+	dateLayout     = "20060102"
+	maxLineLength  = 75
+)
+
+var ErrNoTodo = errors.New("ical: no VTODO component")
+
+// Todo holds the properties of a VTODO component sent by a client.
+type Todo struct {
+	Uid       string
+	Summary   string
+	Status    string
+	Completed *time.Time
+}
+
+// Done reports whether the todo is completed. Clients that don't set
+// the status mark a todo as completed by its completion time.
+func (t Todo) Done() bool {
+	if t.Status != "" {
+		return t.Status == StatusCompleted
+	}
+
+	return t.Completed != nil
+}
+
+// Uid returns the unique identifier of the item's VTODO component.
+func Uid(itemId int) string {
+	return fmt.Sprintf("item-%d@todo-app", itemId)
+}
+
+// WriteCalendar writes a calendar named name with a VTODO component per item.
+func WriteCalendar(w io.Writer, name string, items []todo.TodoItem) error {
+	lw := &lineWriter{w: bufio.NewWriter(w)}
+
+	lw.property("BEGIN", "VCALENDAR")
+	lw.property("VERSION", "2.0")
+	lw.property("PRODID", productId)
+	lw.property("CALSCALE", "GREGORIAN")
+	if name != "" {
+		lw.property("X-WR-CALNAME", escape(name))
+	}
+
+	for _, item := range items {
+		writeTodo(lw, item)
+	}
+
+	lw.property("END", "VCALENDAR")
+	if lw.err != nil {
+		return lw.err
+	}
+
+	return lw.w.Flush()
+}
+
+func writeTodo(lw *lineWriter, item todo.TodoItem) {
+	lw.property("BEGIN", "VTODO")
+	lw.property("UID", Uid(item.Id))
+	lw.property("DTSTAMP", item.UpdatedAt.UTC().Format(dateTimeLayout))
+	lw.property("CREATED", item.CreatedAt.UTC().Format(dateTimeLayout))
+	lw.property("LAST-MODIFIED", item.UpdatedAt.UTC().Format(dateTimeLayout))
+	lw.property("SEQUENCE", fmt.Sprint(item.Version))
+	lw.property("SUMMARY", escape(item.Title))
+	if item.Description != "" {
+		lw.property("DESCRIPTION", escape(item.Description))
+	}
+
+	if item.DueDate != nil {
+		if due, err := time.Parse(todo.DateLayout, *item.DueDate); err == nil {
+			lw.property("DUE;VALUE=DATE", due.Format(dateLayout))
+		}
+	}
+
+	if item.Done {
+		lw.property("STATUS", StatusCompleted)
+		if item.CompletedAt != nil {
+			lw.property("COMPLETED", item.CompletedAt.UTC().Format(dateTimeLayout))
+		}
+	} else {
+		lw.property("STATUS", StatusNeedsAction)
+	}
+
+	if item.ParentId != nil {
+		lw.property("RELATED-TO", Uid(*item.ParentId))
+	}
+
+	lw.property("END", "VTODO")
+}
+
+// lineWriter writes content lines folded to 75 octets, keeping the first error.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) property(name, value string) {
+	if lw.err != nil {
+		return
+	}
+
+	line := name + ":" + value
+	// continuation lines start with a space, which counts towards their length
+	for limit := maxLineLength; len(line) > limit; limit = maxLineLength - 1 {
+		// don't split multi-byte characters
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+
+		if _, lw.err = lw.w.WriteString(line[:cut] + "\r\n "); lw.err != nil {
+			return
+		}
+		line = line[cut:]
+	}
+
+	_, lw.err = lw.w.WriteString(line + "\r\n")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+var unescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// ParseTodo reads the first VTODO component of an iCalendar object.
+func ParseTodo(r io.Reader) (Todo, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return Todo{}, err
+	}
+
+	var t Todo
+	inTodo := false
+	for _, line := range lines {
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+
+		name, value := strings.ToUpper(line[:i]), line[i+1:]
+		if j := strings.Index(name, ";"); j >= 0 {
+			// parameters aren't needed
+			name = name[:j]
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VTODO"):
+			inTodo = true
+		case !inTodo:
+			continue
+		case name == "END" && strings.EqualFold(value, "VTODO"):
+			return t, nil
+		case name == "UID":
+			t.Uid = value
+		case name == "SUMMARY":
+			t.Summary = unescaper.Replace(value)
+		case name == "STATUS":
+			t.Status = strings.ToUpper(value)
+		case name == "COMPLETED":
+			completed, err := parseDateTime(value)
+			if err != nil {
+				return Todo{}, err
+			}
+			t.Completed = &completed
+		}
+	}
+
+	return Todo{}, ErrNoTodo
+}
+
+// unfold splits the object into content lines, joining the lines folded by CRLF followed by whitespace.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+func parseDateTime(value string) (time.Time, error) {
+	for _, layout := range []string{dateTimeLayout, "20060102T150405", dateLayout} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("ical: invalid date-time %q", value)
+}
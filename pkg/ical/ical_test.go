@@ -0,0 +1,104 @@
+package ical
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	createdAt := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2021, 1, 2, 10, 0, 0, 0, time.UTC)
+	dueDate := "2021-01-03"
+	parentId := 1
+
+	items := []todo.TodoItem{
+		{Id: 2, Title: "Milk, eggs; bread", Description: "from the\nmarket", Version: 3, DueDate: &dueDate,
+			CreatedAt: createdAt, UpdatedAt: completedAt, Done: true, CompletedAt: &completedAt, ParentId: &parentId},
+		{Id: 3, Title: strings.Repeat("a", 80), Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	var b bytes.Buffer
+	assert.NoError(t, WriteCalendar(&b, "Groceries", items))
+	assert.Equal(t, "BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"PRODID:-//todo-app//todo-app//EN\r\n"+
+		"CALSCALE:GREGORIAN\r\n"+
+		"X-WR-CALNAME:Groceries\r\n"+
+		"BEGIN:VTODO\r\n"+
+		"UID:item-2@todo-app\r\n"+
+		"DTSTAMP:20210102T100000Z\r\n"+
+		"CREATED:20210101T100000Z\r\n"+
+		"LAST-MODIFIED:20210102T100000Z\r\n"+
+		"SEQUENCE:3\r\n"+
+		"SUMMARY:Milk\\, eggs\\; bread\r\n"+
+		"DESCRIPTION:from the\\nmarket\r\n"+
+		"DUE;VALUE=DATE:20210103\r\n"+
+		"STATUS:COMPLETED\r\n"+
+		"COMPLETED:20210102T100000Z\r\n"+
+		"RELATED-TO:item-1@todo-app\r\n"+
+		"END:VTODO\r\n"+
+		"BEGIN:VTODO\r\n"+
+		"UID:item-3@todo-app\r\n"+
+		"DTSTAMP:20210101T100000Z\r\n"+
+		"CREATED:20210101T100000Z\r\n"+
+		"LAST-MODIFIED:20210101T100000Z\r\n"+
+		"SEQUENCE:1\r\n"+
+		"SUMMARY:"+strings.Repeat("a", 67)+"\r\n "+strings.Repeat("a", 13)+"\r\n"+
+		"STATUS:NEEDS-ACTION\r\n"+
+		"END:VTODO\r\n"+
+		"END:VCALENDAR\r\n", b.String())
+}
+
+func TestParseTodo(t *testing.T) {
+	completedAt := time.Date(2021, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    Todo
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			input: "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:item-2@todo-app\r\nSUMMARY:Milk\\, eg\r\n gs\r\n" +
+				"STATUS:completed\r\nCOMPLETED:20210102T100000Z\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			want: Todo{Uid: "item-2@todo-app", Summary: "Milk, eggs", Status: StatusCompleted, Completed: &completedAt},
+		},
+		{
+			name:  "Without Status",
+			input: "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:item-2@todo-app\nCOMPLETED;VALUE=DATE:20210102\nEND:VTODO\nEND:VCALENDAR\n",
+			want:  Todo{Uid: "item-2@todo-app", Completed: timePointer(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:    "No Todo",
+			input:   "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Completed",
+			input:   "BEGIN:VTODO\r\nCOMPLETED:yesterday\r\nEND:VTODO\r\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTodo(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.want.Completed != nil, got.Done())
+			}
+		})
+	}
+}
+
+func timePointer(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,4 @@
+// Package pb holds the messages and the gRPC services generated from proto/todo.proto.
+package pb
+
+//go:generate protoc -I ../../proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative todo.proto
@@ -0,0 +1,1210 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AuthClient is the client API for Auth service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthClient interface {
+	SignUp(ctx context.Context, in *SignUpRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*SignInResponse, error)
+}
+
+type authClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthClient(cc grpc.ClientConnInterface) AuthClient {
+	return &authClient{cc}
+}
+
+func (c *authClient) SignUp(ctx context.Context, in *SignUpRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/todo.Auth/SignUp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*SignInResponse, error) {
+	out := new(SignInResponse)
+	err := c.cc.Invoke(ctx, "/todo.Auth/SignIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServer is the server API for Auth service.
+// All implementations must embed UnimplementedAuthServer
+// for forward compatibility
+type AuthServer interface {
+	SignUp(context.Context, *SignUpRequest) (*IdResponse, error)
+	SignIn(context.Context, *SignInRequest) (*SignInResponse, error)
+	mustEmbedUnimplementedAuthServer()
+}
+
+// UnimplementedAuthServer must be embedded to have forward compatible implementations.
+type UnimplementedAuthServer struct {
+}
+
+func (UnimplementedAuthServer) SignUp(context.Context, *SignUpRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignUp not implemented")
+}
+func (UnimplementedAuthServer) SignIn(context.Context, *SignInRequest) (*SignInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignIn not implemented")
+}
+func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
+
+// UnsafeAuthServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServer will
+// result in compilation errors.
+type UnsafeAuthServer interface {
+	mustEmbedUnimplementedAuthServer()
+}
+
+func RegisterAuthServer(s grpc.ServiceRegistrar, srv AuthServer) {
+	s.RegisterService(&Auth_ServiceDesc, srv)
+}
+
+func _Auth_SignUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignUpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).SignUp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.Auth/SignUp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).SignUp(ctx, req.(*SignUpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_SignIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).SignIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.Auth/SignIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).SignIn(ctx, req.(*SignInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Auth_ServiceDesc is the grpc.ServiceDesc for Auth service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Auth_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.Auth",
+	HandlerType: (*AuthServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignUp",
+			Handler:    _Auth_SignUp_Handler,
+		},
+		{
+			MethodName: "SignIn",
+			Handler:    _Auth_SignIn_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo.proto",
+}
+
+// TodoListClient is the client API for TodoList service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TodoListClient interface {
+	Create(ctx context.Context, in *CreateListRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	GetAll(ctx context.Context, in *GetAllListsRequest, opts ...grpc.CallOption) (*TodoLists, error)
+	GetById(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*List, error)
+	Update(ctx context.Context, in *UpdateListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Restore(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Move(ctx context.Context, in *MoveListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Archive(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Unarchive(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Duplicate(ctx context.Context, in *DuplicateListRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	Instantiate(ctx context.Context, in *InstantiateListRequest, opts ...grpc.CallOption) (*IdResponse, error)
+}
+
+type todoListClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTodoListClient(cc grpc.ClientConnInterface) TodoListClient {
+	return &todoListClient{cc}
+}
+
+func (c *todoListClient) Create(ctx context.Context, in *CreateListRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) GetAll(ctx context.Context, in *GetAllListsRequest, opts ...grpc.CallOption) (*TodoLists, error) {
+	out := new(TodoLists)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) GetById(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*List, error) {
+	out := new(List)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/GetById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Update(ctx context.Context, in *UpdateListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Patch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Restore(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Move(ctx context.Context, in *MoveListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Move", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Archive(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Archive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Unarchive(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Unarchive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Duplicate(ctx context.Context, in *DuplicateListRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Duplicate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListClient) Instantiate(ctx context.Context, in *InstantiateListRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/todo.TodoList/Instantiate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TodoListServer is the server API for TodoList service.
+// All implementations must embed UnimplementedTodoListServer
+// for forward compatibility
+type TodoListServer interface {
+	Create(context.Context, *CreateListRequest) (*IdResponse, error)
+	GetAll(context.Context, *GetAllListsRequest) (*TodoLists, error)
+	GetById(context.Context, *IdRequest) (*List, error)
+	Update(context.Context, *UpdateListRequest) (*emptypb.Empty, error)
+	Patch(context.Context, *PatchRequest) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	Restore(context.Context, *IdRequest) (*emptypb.Empty, error)
+	Move(context.Context, *MoveListRequest) (*emptypb.Empty, error)
+	Archive(context.Context, *IdRequest) (*emptypb.Empty, error)
+	Unarchive(context.Context, *IdRequest) (*emptypb.Empty, error)
+	Duplicate(context.Context, *DuplicateListRequest) (*IdResponse, error)
+	Instantiate(context.Context, *InstantiateListRequest) (*IdResponse, error)
+	mustEmbedUnimplementedTodoListServer()
+}
+
+// UnimplementedTodoListServer must be embedded to have forward compatible implementations.
+type UnimplementedTodoListServer struct {
+}
+
+func (UnimplementedTodoListServer) Create(context.Context, *CreateListRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedTodoListServer) GetAll(context.Context, *GetAllListsRequest) (*TodoLists, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedTodoListServer) GetById(context.Context, *IdRequest) (*List, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetById not implemented")
+}
+func (UnimplementedTodoListServer) Update(context.Context, *UpdateListRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedTodoListServer) Patch(context.Context, *PatchRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Patch not implemented")
+}
+func (UnimplementedTodoListServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedTodoListServer) Restore(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedTodoListServer) Move(context.Context, *MoveListRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Move not implemented")
+}
+func (UnimplementedTodoListServer) Archive(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Archive not implemented")
+}
+func (UnimplementedTodoListServer) Unarchive(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unarchive not implemented")
+}
+func (UnimplementedTodoListServer) Duplicate(context.Context, *DuplicateListRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Duplicate not implemented")
+}
+func (UnimplementedTodoListServer) Instantiate(context.Context, *InstantiateListRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Instantiate not implemented")
+}
+func (UnimplementedTodoListServer) mustEmbedUnimplementedTodoListServer() {}
+
+// UnsafeTodoListServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TodoListServer will
+// result in compilation errors.
+type UnsafeTodoListServer interface {
+	mustEmbedUnimplementedTodoListServer()
+}
+
+func RegisterTodoListServer(s grpc.ServiceRegistrar, srv TodoListServer) {
+	s.RegisterService(&TodoList_ServiceDesc, srv)
+}
+
+func _TodoList_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Create(ctx, req.(*CreateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).GetAll(ctx, req.(*GetAllListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_GetById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).GetById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/GetById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).GetById(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Update(ctx, req.(*UpdateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Patch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Patch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Patch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Patch(ctx, req.(*PatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Restore(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Move",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Move(ctx, req.(*MoveListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Archive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Archive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Archive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Archive(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Unarchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Unarchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Unarchive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Unarchive(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Duplicate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DuplicateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Duplicate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Duplicate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Duplicate(ctx, req.(*DuplicateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoList_Instantiate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstantiateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServer).Instantiate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoList/Instantiate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServer).Instantiate(ctx, req.(*InstantiateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TodoList_ServiceDesc is the grpc.ServiceDesc for TodoList service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TodoList_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.TodoList",
+	HandlerType: (*TodoListServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _TodoList_Create_Handler,
+		},
+		{
+			MethodName: "GetAll",
+			Handler:    _TodoList_GetAll_Handler,
+		},
+		{
+			MethodName: "GetById",
+			Handler:    _TodoList_GetById_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _TodoList_Update_Handler,
+		},
+		{
+			MethodName: "Patch",
+			Handler:    _TodoList_Patch_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TodoList_Delete_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _TodoList_Restore_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _TodoList_Move_Handler,
+		},
+		{
+			MethodName: "Archive",
+			Handler:    _TodoList_Archive_Handler,
+		},
+		{
+			MethodName: "Unarchive",
+			Handler:    _TodoList_Unarchive_Handler,
+		},
+		{
+			MethodName: "Duplicate",
+			Handler:    _TodoList_Duplicate_Handler,
+		},
+		{
+			MethodName: "Instantiate",
+			Handler:    _TodoList_Instantiate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo.proto",
+}
+
+// TodoItemClient is the client API for TodoItem service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TodoItemClient interface {
+	Create(ctx context.Context, in *CreateItemRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	CreateChild(ctx context.Context, in *CreateChildItemRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	GetAll(ctx context.Context, in *GetAllItemsRequest, opts ...grpc.CallOption) (*TodoItems, error)
+	GetAllByUser(ctx context.Context, in *GetUserItemsRequest, opts ...grpc.CallOption) (*TodoItems, error)
+	GetChildren(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*TodoItems, error)
+	GetById(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Item, error)
+	Update(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Restore(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Move(ctx context.Context, in *MoveItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Archive(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Unarchive(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Copy(ctx context.Context, in *CopyItemRequest, opts ...grpc.CallOption) (*IdResponse, error)
+	Bulk(ctx context.Context, in *BulkItemsRequest, opts ...grpc.CallOption) (*BulkItemsResponse, error)
+}
+
+type todoItemClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTodoItemClient(cc grpc.ClientConnInterface) TodoItemClient {
+	return &todoItemClient{cc}
+}
+
+func (c *todoItemClient) Create(ctx context.Context, in *CreateItemRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) CreateChild(ctx context.Context, in *CreateChildItemRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/CreateChild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) GetAll(ctx context.Context, in *GetAllItemsRequest, opts ...grpc.CallOption) (*TodoItems, error) {
+	out := new(TodoItems)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) GetAllByUser(ctx context.Context, in *GetUserItemsRequest, opts ...grpc.CallOption) (*TodoItems, error) {
+	out := new(TodoItems)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/GetAllByUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) GetChildren(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*TodoItems, error) {
+	out := new(TodoItems)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/GetChildren", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) GetById(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Item, error) {
+	out := new(Item)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/GetById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Update(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Patch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Restore(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Move(ctx context.Context, in *MoveItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Move", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Archive(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Archive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Unarchive(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Unarchive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Copy(ctx context.Context, in *CopyItemRequest, opts ...grpc.CallOption) (*IdResponse, error) {
+	out := new(IdResponse)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Copy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoItemClient) Bulk(ctx context.Context, in *BulkItemsRequest, opts ...grpc.CallOption) (*BulkItemsResponse, error) {
+	out := new(BulkItemsResponse)
+	err := c.cc.Invoke(ctx, "/todo.TodoItem/Bulk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TodoItemServer is the server API for TodoItem service.
+// All implementations must embed UnimplementedTodoItemServer
+// for forward compatibility
+type TodoItemServer interface {
+	Create(context.Context, *CreateItemRequest) (*IdResponse, error)
+	CreateChild(context.Context, *CreateChildItemRequest) (*IdResponse, error)
+	GetAll(context.Context, *GetAllItemsRequest) (*TodoItems, error)
+	GetAllByUser(context.Context, *GetUserItemsRequest) (*TodoItems, error)
+	GetChildren(context.Context, *IdRequest) (*TodoItems, error)
+	GetById(context.Context, *IdRequest) (*Item, error)
+	Update(context.Context, *UpdateItemRequest) (*emptypb.Empty, error)
+	Patch(context.Context, *PatchRequest) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	Restore(context.Context, *IdRequest) (*emptypb.Empty, error)
+	Move(context.Context, *MoveItemRequest) (*emptypb.Empty, error)
+	Archive(context.Context, *IdRequest) (*emptypb.Empty, error)
+	Unarchive(context.Context, *IdRequest) (*emptypb.Empty, error)
+	Copy(context.Context, *CopyItemRequest) (*IdResponse, error)
+	Bulk(context.Context, *BulkItemsRequest) (*BulkItemsResponse, error)
+	mustEmbedUnimplementedTodoItemServer()
+}
+
+// UnimplementedTodoItemServer must be embedded to have forward compatible implementations.
+type UnimplementedTodoItemServer struct {
+}
+
+func (UnimplementedTodoItemServer) Create(context.Context, *CreateItemRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedTodoItemServer) CreateChild(context.Context, *CreateChildItemRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChild not implemented")
+}
+func (UnimplementedTodoItemServer) GetAll(context.Context, *GetAllItemsRequest) (*TodoItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedTodoItemServer) GetAllByUser(context.Context, *GetUserItemsRequest) (*TodoItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllByUser not implemented")
+}
+func (UnimplementedTodoItemServer) GetChildren(context.Context, *IdRequest) (*TodoItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChildren not implemented")
+}
+func (UnimplementedTodoItemServer) GetById(context.Context, *IdRequest) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetById not implemented")
+}
+func (UnimplementedTodoItemServer) Update(context.Context, *UpdateItemRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedTodoItemServer) Patch(context.Context, *PatchRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Patch not implemented")
+}
+func (UnimplementedTodoItemServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedTodoItemServer) Restore(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedTodoItemServer) Move(context.Context, *MoveItemRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Move not implemented")
+}
+func (UnimplementedTodoItemServer) Archive(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Archive not implemented")
+}
+func (UnimplementedTodoItemServer) Unarchive(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unarchive not implemented")
+}
+func (UnimplementedTodoItemServer) Copy(context.Context, *CopyItemRequest) (*IdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Copy not implemented")
+}
+func (UnimplementedTodoItemServer) Bulk(context.Context, *BulkItemsRequest) (*BulkItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Bulk not implemented")
+}
+func (UnimplementedTodoItemServer) mustEmbedUnimplementedTodoItemServer() {}
+
+// UnsafeTodoItemServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TodoItemServer will
+// result in compilation errors.
+type UnsafeTodoItemServer interface {
+	mustEmbedUnimplementedTodoItemServer()
+}
+
+func RegisterTodoItemServer(s grpc.ServiceRegistrar, srv TodoItemServer) {
+	s.RegisterService(&TodoItem_ServiceDesc, srv)
+}
+
+func _TodoItem_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Create(ctx, req.(*CreateItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_CreateChild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChildItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).CreateChild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/CreateChild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).CreateChild(ctx, req.(*CreateChildItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).GetAll(ctx, req.(*GetAllItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_GetAllByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).GetAllByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/GetAllByUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).GetAllByUser(ctx, req.(*GetUserItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_GetChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).GetChildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/GetChildren",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).GetChildren(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_GetById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).GetById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/GetById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).GetById(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Update(ctx, req.(*UpdateItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Patch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Patch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Patch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Patch(ctx, req.(*PatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Restore(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Move",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Move(ctx, req.(*MoveItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Archive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Archive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Archive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Archive(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Unarchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Unarchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Unarchive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Unarchive(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Copy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Copy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Copy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Copy(ctx, req.(*CopyItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoItem_Bulk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoItemServer).Bulk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/todo.TodoItem/Bulk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoItemServer).Bulk(ctx, req.(*BulkItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TodoItem_ServiceDesc is the grpc.ServiceDesc for TodoItem service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TodoItem_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.TodoItem",
+	HandlerType: (*TodoItemServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _TodoItem_Create_Handler,
+		},
+		{
+			MethodName: "CreateChild",
+			Handler:    _TodoItem_CreateChild_Handler,
+		},
+		{
+			MethodName: "GetAll",
+			Handler:    _TodoItem_GetAll_Handler,
+		},
+		{
+			MethodName: "GetAllByUser",
+			Handler:    _TodoItem_GetAllByUser_Handler,
+		},
+		{
+			MethodName: "GetChildren",
+			Handler:    _TodoItem_GetChildren_Handler,
+		},
+		{
+			MethodName: "GetById",
+			Handler:    _TodoItem_GetById_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _TodoItem_Update_Handler,
+		},
+		{
+			MethodName: "Patch",
+			Handler:    _TodoItem_Patch_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TodoItem_Delete_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _TodoItem_Restore_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _TodoItem_Move_Handler,
+		},
+		{
+			MethodName: "Archive",
+			Handler:    _TodoItem_Archive_Handler,
+		},
+		{
+			MethodName: "Unarchive",
+			Handler:    _TodoItem_Unarchive_Handler,
+		},
+		{
+			MethodName: "Copy",
+			Handler:    _TodoItem_Copy_Handler,
+		},
+		{
+			MethodName: "Bulk",
+			Handler:    _TodoItem_Bulk_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo.proto",
+}
@@ -0,0 +1,170 @@
+// Package rank generates fractional index keys used to keep lists and items
+// in a user defined order. Keys are compared byte by byte, so a new key can
+// always be placed between two existing ones without touching other rows.
+//
+// Keys added at the ends grow logarithmically: after k leading "z" digits comes
+// an integer of k+1 digits, which After increments, and Before decrements the
+// integer after leading "0" digits the same way. Only keys inserted between two
+// others repeatedly at the same place grow linearly.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var ErrInvalidOrder = errors.New("rank: prev key must be less than next key")
+
+// Between returns a key that sorts after prev and before next.
+// An empty prev means the start of the sequence, an empty next means the end.
+func Between(prev, next string) (string, error) {
+	if next != "" && prev >= next {
+		return "", ErrInvalidOrder
+	}
+
+	switch {
+	case next == "":
+		return After(prev), nil
+	case prev == "":
+		return Before(next), nil
+	}
+
+	return midpoint(prev, next), nil
+}
+
+// After returns a key that sorts after prev.
+func After(prev string) string {
+	if prev == "" {
+		return midpoint("", "")
+	}
+
+	k := leading(prev, lastDigit)
+	// the integer can't overflow, its first digit isn't the last one
+	n := increment(integer(prev, k))
+	if n[len(n)-1] == firstDigit {
+		n = increment(n)
+	}
+
+	return prev[:k] + n
+}
+
+// Before returns a key that sorts before next.
+func Before(next string) string {
+	if next == "" {
+		return midpoint("", "")
+	}
+
+	k := leading(next, firstDigit)
+	// the integer can't underflow, its first digit isn't zero as next has no trailing zeros
+	n := decrement(integer(next, k))
+	if n[len(n)-1] == firstDigit {
+		n += string(lastDigit)
+	}
+
+	return next[:k] + n
+}
+
+// midpoint expects a < b (an empty b has no upper bound)
+// and keys without trailing zero digits.
+func midpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(suffix(a, n), b[n:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(digits, a[0])
+	}
+	digitB := len(digits)
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(digits[(digitA+digitB+1)/2])
+	}
+
+	if len(b) > 1 {
+		return b[:1]
+	}
+
+	return string(digits[digitA]) + midpoint(suffix(a, 1), "")
+}
+
+const (
+	firstDigit = '0'
+	lastDigit  = 'z'
+)
+
+// leading returns the number of digits d the key starts with.
+func leading(key string, d byte) int {
+	k := 0
+	for k < len(key) && key[k] == d {
+		k++
+	}
+
+	return k
+}
+
+// integer returns k+1 digits of the key after the first k, padded with zeros.
+func integer(key string, k int) string {
+	n := []byte(suffix(key, k))
+	if len(n) > k+1 {
+		n = n[:k+1]
+	}
+	for len(n) < k+1 {
+		n = append(n, firstDigit)
+	}
+
+	return string(n)
+}
+
+func increment(n string) string {
+	b := []byte(n)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] != lastDigit {
+			b[i] = digits[strings.IndexByte(digits, b[i])+1]
+			break
+		}
+		b[i] = firstDigit
+	}
+
+	return string(b)
+}
+
+func decrement(n string) string {
+	b := []byte(n)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] != firstDigit {
+			b[i] = digits[strings.IndexByte(digits, b[i])-1]
+			break
+		}
+		b[i] = lastDigit
+	}
+
+	return string(b)
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+
+	return digits[0]
+}
+
+func suffix(s string, i int) string {
+	if i < len(s) {
+		return s[i:]
+	}
+
+	return ""
+}
@@ -0,0 +1,140 @@
+package rank
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		prev    string
+		next    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Empty",
+			want: "i",
+		},
+		{
+			name: "Append",
+			prev: "i",
+			want: "j",
+		},
+		{
+			name: "Prepend",
+			next: "i",
+			want: "h",
+		},
+		{
+			name: "Adjacent Digits",
+			prev: "i",
+			next: "j",
+			want: "ii",
+		},
+		{
+			name: "Common Prefix",
+			prev: "ab",
+			next: "ad",
+			want: "ac",
+		},
+		{
+			name: "Longer Next",
+			prev: "a",
+			next: "b5",
+			want: "b",
+		},
+		{
+			name: "Before Zero Prefixed",
+			next: "0000000001i",
+			want: "0000000001hzzzzzzzz",
+		},
+		{
+			name: "Append Last Digit",
+			prev: "y",
+			want: "z",
+		},
+		{
+			name: "Append Next Level",
+			prev: "z",
+			want: "z01",
+		},
+		{
+			name: "Append Skips Trailing Zero",
+			prev: "z0z",
+			want: "z11",
+		},
+		{
+			name: "Append After Zero Prefixed",
+			prev: "0000000001i",
+			want: "1",
+		},
+		{
+			name: "Prepend Trailing Zero",
+			next: "1",
+			want: "0z",
+		},
+		{
+			name:    "Invalid Order",
+			prev:    "b",
+			next:    "a",
+			wantErr: true,
+		},
+		{
+			name:    "Equal Keys",
+			prev:    "a",
+			next:    "a",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.prev, tt.next)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBetween_Repeated(t *testing.T) {
+	prev, next := "a", "b"
+	for i := 0; i < 100; i++ {
+		key, err := Between(prev, next)
+		assert.NoError(t, err)
+		assert.True(t, prev < key && key < next, "%q must be between %q and %q", key, prev, next)
+
+		if i%2 == 0 {
+			prev = key
+		} else {
+			next = key
+		}
+	}
+
+	key := ""
+	for i := 0; i < 100; i++ {
+		newKey := After(key)
+		assert.True(t, key < newKey)
+		key = newKey
+	}
+}
+
+func TestAfter_Length(t *testing.T) {
+	after, before := "", ""
+	for i := 0; i < 100000; i++ {
+		newAfter, newBefore := After(after), Before(before)
+		assert.True(t, after < newAfter, "%q must be after %q", newAfter, after)
+		assert.True(t, before == "" || newBefore < before, "%q must be before %q", newBefore, before)
+		assert.NotEqual(t, '0', newAfter[len(newAfter)-1])
+		assert.NotEqual(t, '0', newBefore[len(newBefore)-1])
+		after, before = newAfter, newBefore
+	}
+
+	assert.True(t, len(after) <= 7, "%q is too long", after)
+	assert.True(t, len(before) <= 7, "%q is too long", before)
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/zhashkevych/todo-app"
+	"time"
+)
+
+const activityColumns = "a.id, a.actor_id, a.list_id, a.item_id, a.action, a.changes, a.version, a.transaction_id, " +
+	"a.reverts, a.undone_at, a.created_at"
+
+// finishedCond selects the activity of the transactions older than any one still in progress.
+// Transactions may commit in a different order than they got their ids, so the activity is read
+// in the order of transactions and only once no earlier transaction can add to it anymore.
+//
+// The oldest transaction in progress is the oldest one of the whole cluster, not only of the
+// activity log, so a single long running or idle in transaction session of any database would
+// hold back every stream. Activity recorded more than 30 seconds ago is read anyway, at the cost
+// of streams missing the activity of transactions that run longer than that.
+const finishedCond = "(a.transaction_id < txid_snapshot_xmin(txid_current_snapshot()) OR a.created_at < now() - interval '30 seconds')"
+
+type ActivityPostgres struct {
+	db Database
+}
+
+func NewActivityPostgres(db Database) *ActivityPostgres {
+	return &ActivityPostgres{db: db}
+}
+
+// Create appends the activity to the log along with the current version of the item or the list.
+func (r *ActivityPostgres) Create(activity todo.Activity) error {
+	query := fmt.Sprintf(`INSERT INTO %s (actor_id, list_id, item_id, action, changes, reverts, version)
+									VALUES ($1, $2, $3, $4, $5, $6,
+									COALESCE((SELECT version FROM %s WHERE id = $3), (SELECT version FROM %s WHERE id = $2)))`,
+		activityLogTable, todoItemsTable, todoListsTable)
+	_, err := r.db.Exec(query, activity.ActorId, activity.ListId, activity.ItemId, activity.Action, activity.Changes, activity.Reverts)
+
+	return err
+}
+
+// GetAll returns the activity of all lists shared with the user.
+func (r *ActivityPostgres) GetAll(userId int, filter todo.ActivityFilter) ([]todo.Activity, error) {
+	var activity []todo.Activity
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s ul on a.list_id = ul.list_id
+									WHERE ul.user_id = $1 ORDER BY a.id DESC LIMIT $2 OFFSET $3`,
+		activityColumns, activityLogTable, usersListsTable)
+	err := r.db.Select(&activity, query, userId, filter.Limit, filter.Offset)
+
+	return activity, err
+}
+
+func (r *ActivityPostgres) GetByList(userId, listId int, filter todo.ActivityFilter) ([]todo.Activity, error) {
+	var activity []todo.Activity
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s ul on a.list_id = ul.list_id
+									WHERE ul.user_id = $1 AND a.list_id = $2 ORDER BY a.id DESC LIMIT $3 OFFSET $4`,
+		activityColumns, activityLogTable, usersListsTable)
+	err := r.db.Select(&activity, query, userId, listId, filter.Limit, filter.Offset)
+
+	return activity, err
+}
+
+// GetSince returns up to limit entries of the activity of the user's lists recorded after the entry
+// afterId, in the order of their transactions. If the entry has been removed with its list,
+// the entries of the transactions before the ones of later entries are skipped.
+func (r *ActivityPostgres) GetSince(userId, afterId, limit int) ([]todo.Activity, error) {
+	var activity []todo.Activity
+	query := fmt.Sprintf(`SELECT %s FROM %s a INNER JOIN %s ul on a.list_id = ul.list_id
+									WHERE ul.user_id = $1 AND %s AND (a.transaction_id, a.id) > (COALESCE(
+										(SELECT transaction_id FROM %s WHERE id = $2),
+										(SELECT MAX(transaction_id) FROM %s WHERE id < $2), 0), $2)
+									ORDER BY a.transaction_id, a.id LIMIT $3`,
+		activityColumns, activityLogTable, usersListsTable, finishedCond, activityLogTable, activityLogTable)
+	err := r.db.Select(&activity, query, userId, afterId, limit)
+
+	return activity, err
+}
+
+// GetLastId returns the id of the latest entry of the activity of the user's lists or 0 if there is none.
+func (r *ActivityPostgres) GetLastId(userId int) (int, error) {
+	var id int
+	query := fmt.Sprintf(`SELECT COALESCE((SELECT a.id FROM %s a INNER JOIN %s ul on a.list_id = ul.list_id
+									WHERE ul.user_id = $1 AND %s ORDER BY a.transaction_id DESC, a.id DESC LIMIT 1), 0)`,
+		activityLogTable, usersListsTable, finishedCond)
+	err := r.db.Get(&id, query, userId)
+
+	return id, err
+}
+
+// GetListUsers returns the ids of the users the list is shared with.
+func (r *ActivityPostgres) GetListUsers(listId int) ([]int, error) {
+	var userIds []int
+	query := fmt.Sprintf("SELECT ul.user_id FROM %s ul WHERE ul.list_id = $1", usersListsTable)
+	err := r.db.Select(&userIds, query, listId)
+
+	return userIds, err
+}
+
+// GetOperation returns the activity of the operation the given activity belongs to or of the latest
+// operation of the user if activityId is nil, newest entries first. Only the activity made by the user
+// after since and not undone yet is returned. Operations undoing others aren't considered the latest.
+func (r *ActivityPostgres) GetOperation(userId int, activityId *int, since time.Time) ([]todo.Activity, error) {
+	args := []interface{}{userId, since}
+	operationCond := "reverts IS NULL"
+	if activityId != nil {
+		operationCond = "id = $3"
+		args = append(args, *activityId)
+	}
+
+	var activity []todo.Activity
+	query := fmt.Sprintf(`SELECT %s FROM %s a WHERE a.actor_id = $1 AND a.undone_at IS NULL AND a.transaction_id = (
+									SELECT transaction_id FROM %s WHERE actor_id = $1 AND undone_at IS NULL AND created_at > $2 AND %s
+									ORDER BY id DESC LIMIT 1)
+								ORDER BY a.id DESC`,
+		activityColumns, activityLogTable, activityLogTable, operationCond)
+	err := r.db.Select(&activity, query, args...)
+
+	return activity, err
+}
+
+func (r *ActivityPostgres) SetUndone(activityId int) error {
+	query := fmt.Sprintf("UPDATE %s SET undone_at = now() WHERE id = $1", activityLogTable)
+	_, err := r.db.Exec(query, activityId)
+
+	return err
+}
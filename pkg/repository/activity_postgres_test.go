@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	"github.com/zhashkevych/todo-app"
+	"testing"
+	"time"
+)
+
+func TestActivityPostgres_Create(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewActivityPostgres(db)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		input   todo.Activity
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectExec("INSERT INTO activity_log").
+					WithArgs(1, 2, 3, "update", []byte(`{"title":{"from":"old","to":"new"}}`), nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			input: todo.Activity{
+				ActorId: intPointer(1),
+				ListId:  2,
+				ItemId:  intPointer(3),
+				Action:  todo.ActivityUpdate,
+				Changes: todo.Changes{"title": {From: "old", To: "new"}},
+			},
+		},
+		{
+			name: "No Changes",
+			mock: func() {
+				mock.ExpectExec("INSERT INTO activity_log").
+					WithArgs(1, 2, nil, "delete", nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			input: todo.Activity{
+				ActorId: intPointer(1),
+				ListId:  2,
+				Action:  todo.ActivityDelete,
+			},
+		},
+		{
+			name: "Failed Insert",
+			mock: func() {
+				mock.ExpectExec("INSERT INTO activity_log").
+					WithArgs(1, 2, nil, "delete", nil, nil).
+					WillReturnError(errors.New("some error"))
+			},
+			input: todo.Activity{
+				ActorId: intPointer(1),
+				ListId:  2,
+				Action:  todo.ActivityDelete,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.Create(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestActivityPostgres_GetByList(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewActivityPostgres(db)
+
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "actor_id", "list_id", "item_id", "action", "changes", "version", "transaction_id",
+		"reverts", "undone_at", "created_at"}
+
+	type args struct {
+		userId int
+		listId int
+		filter todo.ActivityFilter
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    []todo.Activity
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 1, 1, 3, "update", []byte(`{"done":{"from":false,"to":true}}`), 2, 10, nil, nil, createdAt).
+					AddRow(1, 1, 1, nil, "create", []byte(`{"title":{"from":null,"to":"list"}}`), 1, 9, nil, nil, createdAt)
+
+				mock.ExpectQuery("SELECT (.+) FROM activity_log a INNER JOIN users_lists ul on (.+) WHERE (.+) ORDER BY a.id DESC LIMIT (.+) OFFSET (.+)").
+					WithArgs(1, 1, 50, 0).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				listId: 1,
+				filter: todo.ActivityFilter{Limit: 50},
+			},
+			want: []todo.Activity{
+				{
+					Id:            2,
+					ActorId:       intPointer(1),
+					ListId:        1,
+					ItemId:        intPointer(3),
+					Action:        todo.ActivityUpdate,
+					Changes:       todo.Changes{"done": {From: false, To: true}},
+					Version:       intPointer(2),
+					TransactionId: 10,
+					CreatedAt:     createdAt,
+				},
+				{
+					Id:            1,
+					ActorId:       intPointer(1),
+					ListId:        1,
+					Action:        todo.ActivityCreate,
+					Changes:       todo.Changes{"title": {From: nil, To: "list"}},
+					Version:       intPointer(1),
+					TransactionId: 9,
+					CreatedAt:     createdAt,
+				},
+			},
+		},
+		{
+			name: "No Records",
+			mock: func() {
+				rows := sqlmock.NewRows(columns)
+
+				mock.ExpectQuery("SELECT (.+) FROM activity_log a INNER JOIN users_lists ul on (.+) WHERE (.+)").
+					WithArgs(1, 1, 10, 10).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				listId: 1,
+				filter: todo.ActivityFilter{Limit: 10, Offset: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetByList(tt.input.userId, tt.input.listId, tt.input.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestActivityPostgres_GetOperation(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewActivityPostgres(db)
+
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	since := createdAt.Add(-time.Hour)
+	columns := []string{"id", "actor_id", "list_id", "item_id", "action", "changes", "version", "transaction_id",
+		"reverts", "undone_at", "created_at"}
+
+	type args struct {
+		userId     int
+		activityId *int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    []todo.Activity
+		wantErr bool
+	}{
+		{
+			name: "Latest",
+			mock: func() {
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 1, 1, 3, "delete", nil, 2, 10, nil, nil, createdAt)
+
+				mock.ExpectQuery("SELECT (.+) FROM activity_log a WHERE (.+) AND a.transaction_id = \\( SELECT (.+) AND reverts IS NULL (.+)\\)").
+					WithArgs(1, since).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+			},
+			want: []todo.Activity{
+				{
+					Id:            2,
+					ActorId:       intPointer(1),
+					ListId:        1,
+					ItemId:        intPointer(3),
+					Action:        todo.ActivityDelete,
+					Version:       intPointer(2),
+					TransactionId: 10,
+					CreatedAt:     createdAt,
+				},
+			},
+		},
+		{
+			name: "By Activity Id",
+			mock: func() {
+				rows := sqlmock.NewRows(columns).
+					AddRow(5, 1, 1, 3, "restore", nil, 3, 11, 2, nil, createdAt)
+
+				mock.ExpectQuery("SELECT (.+) FROM activity_log a WHERE (.+) AND a.transaction_id = \\( SELECT (.+) AND id = (.+)\\)").
+					WithArgs(1, since, 5).WillReturnRows(rows)
+			},
+			input: args{
+				userId:     1,
+				activityId: intPointer(5),
+			},
+			want: []todo.Activity{
+				{
+					Id:            5,
+					ActorId:       intPointer(1),
+					ListId:        1,
+					ItemId:        intPointer(3),
+					Action:        todo.ActivityRestore,
+					Version:       intPointer(3),
+					TransactionId: 11,
+					Reverts:       intPointer(2),
+					CreatedAt:     createdAt,
+				},
+			},
+		},
+		{
+			name: "Nothing To Undo",
+			mock: func() {
+				rows := sqlmock.NewRows(columns)
+
+				mock.ExpectQuery("SELECT (.+) FROM activity_log a WHERE (.+)").
+					WithArgs(1, since).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetOperation(tt.input.userId, tt.input.activityId, since)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestActivityPostgres_GetSince(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewActivityPostgres(db)
+
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "actor_id", "list_id", "item_id", "action", "changes", "version", "transaction_id",
+		"reverts", "undone_at", "created_at"}
+
+	type args struct {
+		userId  int
+		afterId int
+		limit   int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    []todo.Activity
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows(columns).
+					AddRow(6, 2, 1, nil, "create", nil, 1, 10, nil, nil, createdAt).
+					AddRow(7, 2, 1, 3, "delete", nil, 2, 11, nil, nil, createdAt)
+
+				mock.ExpectQuery("SELECT (.+) FROM activity_log a INNER JOIN users_lists ul on (.+) WHERE ul.user_id = (.+) AND \\(a.transaction_id < txid_snapshot_xmin\\(txid_current_snapshot\\(\\)\\) OR a.created_at < now\\(\\) - interval '30 seconds'\\) AND \\(a.transaction_id, a.id\\) > (.+) ORDER BY a.transaction_id, a.id LIMIT (.+)").
+					WithArgs(1, 5, 100).WillReturnRows(rows)
+			},
+			input: args{
+				userId:  1,
+				afterId: 5,
+				limit:   100,
+			},
+			want: []todo.Activity{
+				{Id: 6, ActorId: intPointer(2), ListId: 1, Action: todo.ActivityCreate, Version: intPointer(1), TransactionId: 10, CreatedAt: createdAt},
+				{Id: 7, ActorId: intPointer(2), ListId: 1, ItemId: intPointer(3), Action: todo.ActivityDelete, Version: intPointer(2), TransactionId: 11, CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "No Records",
+			mock: func() {
+				rows := sqlmock.NewRows(columns)
+
+				mock.ExpectQuery("SELECT (.+) FROM activity_log a INNER JOIN users_lists ul on (.+) WHERE (.+)").
+					WithArgs(1, 7, 100).WillReturnRows(rows)
+			},
+			input: args{
+				userId:  1,
+				afterId: 7,
+				limit:   100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetSince(tt.input.userId, tt.input.afterId, tt.input.limit)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,15 +2,14 @@ package repository
 
 import (
 	"fmt"
-	"github.com/jmoiron/sqlx"
 	"github.com/zhashkevych/todo-app"
 )
 
 type AuthPostgres struct {
-	db *sqlx.DB
+	db Database
 }
 
-func NewAuthPostgres(db *sqlx.DB) *AuthPostgres {
+func NewAuthPostgres(db Database) *AuthPostgres {
 	return &AuthPostgres{db: db}
 }
 
@@ -32,4 +31,12 @@ func (r *AuthPostgres) GetUser(username, password string) (todo.User, error) {
 	err := r.db.Get(&user, query, username, password)
 
 	return user, err
-}
\ No newline at end of file
+}
+
+func (r *AuthPostgres) GetUserById(userId int) (todo.User, error) {
+	var user todo.User
+	query := fmt.Sprintf("SELECT id, name, username FROM %s WHERE id=$1", usersTable)
+	err := r.db.Get(&user, query, userId)
+
+	return user, err
+}
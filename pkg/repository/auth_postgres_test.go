@@ -133,3 +133,62 @@ func TestAuthPostgres_GetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthPostgres_GetUserById(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewAuthPostgres(db)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		input   int
+		want    todo.User
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "username"}).
+					AddRow(1, "Test", "test")
+				mock.ExpectQuery("SELECT id, name, username FROM users WHERE id=(.+)").
+					WithArgs(1).WillReturnRows(rows)
+			},
+			input: 1,
+			want: todo.User{
+				Id:       1,
+				Name:     "Test",
+				Username: "test",
+			},
+		},
+		{
+			name: "Not Found",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "username"})
+				mock.ExpectQuery("SELECT id, name, username FROM users WHERE id=(.+)").
+					WithArgs(2).WillReturnRows(rows)
+			},
+			input:   2,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetUserById(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
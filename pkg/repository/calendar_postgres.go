@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/zhashkevych/todo-app"
+)
+
+type CalendarPostgres struct {
+	db Database
+}
+
+func NewCalendarPostgres(db Database) *CalendarPostgres {
+	return &CalendarPostgres{db: db}
+}
+
+// SetToken replaces the hash of the user's calendar token, a nil hash revokes the token.
+func (r *CalendarPostgres) SetToken(userId int, tokenHash *string) error {
+	query := fmt.Sprintf("UPDATE %s SET calendar_token = $1 WHERE id = $2", usersTable)
+	_, err := r.db.Exec(query, tokenHash, userId)
+
+	return err
+}
+
+func (r *CalendarPostgres) GetUserId(tokenHash string) (int, error) {
+	var id int
+	query := fmt.Sprintf("SELECT id FROM %s WHERE calendar_token = $1", usersTable)
+	err := r.db.Get(&id, query, tokenHash)
+
+	return id, err
+}
+
+// GetDueItems returns the items with a due date of the user's lists that aren't archived or deleted.
+func (r *CalendarPostgres) GetDueItems(userId int) ([]todo.TodoItem, error) {
+	var items []todo.TodoItem
+	query := fmt.Sprintf(`SELECT %s FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id
+									INNER JOIN %s tl on tl.id = li.list_id
+									WHERE ul.user_id = $1 AND ti.due_date IS NOT NULL AND ti.deleted_at IS NULL AND NOT ti.archived
+									AND tl.deleted_at IS NULL AND NOT tl.archived ORDER BY ti.due_date, ti.id`,
+		todoItemColumns, todoItemsTable, listsItemsTable, usersListsTable, todoListsTable)
+	if err := r.db.Select(&items, query, userId); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
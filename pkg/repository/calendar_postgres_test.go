@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	"testing"
+)
+
+func TestCalendarPostgres_GetUserId(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewCalendarPostgres(db)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+				mock.ExpectQuery("SELECT id FROM users WHERE calendar_token = (.+)").
+					WithArgs("hash").WillReturnRows(rows)
+			},
+			input: "hash",
+			want:  1,
+		},
+		{
+			name: "Not Found",
+			mock: func() {
+				mock.ExpectQuery("SELECT id FROM users WHERE calendar_token = (.+)").
+					WithArgs("hash").WillReturnError(sql.ErrNoRows)
+			},
+			input:   "hash",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetUserId(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCalendarPostgres_SetToken(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewCalendarPostgres(db)
+
+	tests := []struct {
+		name  string
+		mock  func()
+		input *string
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectExec("UPDATE users SET calendar_token = (.+) WHERE id = (.+)").
+					WithArgs("hash", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: stringPointer("hash"),
+		},
+		{
+			name: "Revoke",
+			mock: func() {
+				mock.ExpectExec("UPDATE users SET calendar_token = (.+) WHERE id = (.+)").
+					WithArgs(nil, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			assert.NoError(t, r.SetToken(1, tt.input))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/zhashkevych/todo-app"
+	"time"
+)
+
+type IdempotencyPostgres struct {
+	db Database
+}
+
+func NewIdempotencyPostgres(db Database) *IdempotencyPostgres {
+	return &IdempotencyPostgres{db: db}
+}
+
+// Reserve stores the key for a new request and reports whether it wasn't in use.
+// Keys of the user created before expiredBefore are removed first, so they can be reused.
+func (r *IdempotencyPostgres) Reserve(userId int, key, requestHash string, expiredBefore time.Time) (bool, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return false, err
+	}
+
+	deleteExpiredQuery := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1 AND created_at < $2", idempotencyKeysTable)
+	_, err = tx.Exec(deleteExpiredQuery, userId, expiredBefore)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	reserveQuery := fmt.Sprintf("INSERT INTO %s (user_id, key, request_hash) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		idempotencyKeysTable)
+	res, err := tx.Exec(reserveQuery, userId, key, requestHash)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	reserved, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return reserved > 0, tx.Commit()
+}
+
+func (r *IdempotencyPostgres) Get(userId int, key string) (todo.IdempotencyKey, error) {
+	var idempotencyKey todo.IdempotencyKey
+	query := fmt.Sprintf(`SELECT key, request_hash, status_code, response_headers, response_body FROM %s
+									WHERE user_id = $1 AND key = $2`, idempotencyKeysTable)
+	err := r.db.Get(&idempotencyKey, query, userId, key)
+
+	return idempotencyKey, err
+}
+
+func (r *IdempotencyPostgres) Complete(userId int, key string, statusCode int, headers todo.ResponseHeaders, body []byte) error {
+	query := fmt.Sprintf(`UPDATE %s SET status_code = $1, response_headers = $2, response_body = $3
+									WHERE user_id = $4 AND key = $5`, idempotencyKeysTable)
+	_, err := r.db.Exec(query, statusCode, headers, body, userId, key)
+
+	return err
+}
+
+// Release removes the key of a request that failed, so it can be retried.
+func (r *IdempotencyPostgres) Release(userId int, key string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1 AND key = $2", idempotencyKeysTable)
+	_, err := r.db.Exec(query, userId, key)
+
+	return err
+}
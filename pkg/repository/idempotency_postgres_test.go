@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyPostgres_Reserve(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewIdempotencyPostgres(db)
+
+	expiredBefore := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		userId      int
+		key         string
+		requestHash string
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("DELETE FROM idempotency_keys WHERE (.+)").
+					WithArgs(1, expiredBefore).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WithArgs(1, "key", "hash").WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:      1,
+				key:         "key",
+				requestHash: "hash",
+			},
+			want: true,
+		},
+		{
+			name: "Key In Use",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("DELETE FROM idempotency_keys WHERE (.+)").
+					WithArgs(1, expiredBefore).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WithArgs(1, "key", "hash").WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:      1,
+				key:         "key",
+				requestHash: "hash",
+			},
+			want: false,
+		},
+		{
+			name: "Failed Insert",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("DELETE FROM idempotency_keys WHERE (.+)").
+					WithArgs(1, expiredBefore).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WithArgs(1, "key", "hash").WillReturnError(errors.New("some error"))
+
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId:      1,
+				key:         "key",
+				requestHash: "hash",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.Reserve(tt.input.userId, tt.input.key, tt.input.requestHash, expiredBefore)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/lib/pq"
+	"time"
+)
+
+// activityChannel is notified with the list id whenever an activity is recorded.
+const activityChannel = "activity"
+
+// NewActivityListener listens to the notifications about the activity recorded by any instance of the app.
+// It uses a dedicated connection, which is re-established if it's lost.
+func NewActivityListener(cfg Config) (*pq.Listener, error) {
+	listener := pq.NewListener(dataSourceName(cfg), 10*time.Second, time.Minute, nil)
+	if err := listener.Listen(activityChannel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
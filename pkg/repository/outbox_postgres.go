@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/zhashkevych/todo-app"
+	"time"
+)
+
+const outboxColumns = "o.id, o.event, o.list_id, o.item_id, o.payload, o.attempts, o.created_at"
+
+type OutboxPostgres struct {
+	db Database
+}
+
+func NewOutboxPostgres(db Database) *OutboxPostgres {
+	return &OutboxPostgres{db: db}
+}
+
+func (r *OutboxPostgres) Add(event todo.OutboxEvent) error {
+	query := fmt.Sprintf("INSERT INTO %s (event, list_id, item_id, payload) VALUES ($1, $2, $3, $4)", outboxTable)
+	_, err := r.db.Exec(query, event.Event, event.ListId, event.ItemId, []byte(event.Payload))
+
+	return err
+}
+
+// Claim returns up to limit unpublished events that are due in the order they were added and
+// postpones their next attempt by lease, so that they aren't claimed again while being published.
+func (r *OutboxPostgres) Claim(limit int, lease time.Duration) ([]todo.OutboxEvent, error) {
+	var events []todo.OutboxEvent
+	query := fmt.Sprintf(`WITH due AS (
+									SELECT id FROM %s WHERE published_at IS NULL AND next_attempt_at <= now()
+									ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED),
+								claimed AS (
+									UPDATE %s o SET next_attempt_at = now() + $2 * interval '1 second' FROM due
+									WHERE o.id = due.id RETURNING o.*)
+								SELECT %s FROM claimed o ORDER BY o.id`,
+		outboxTable, outboxTable, outboxColumns)
+	err := r.db.Select(&events, query, limit, lease.Seconds())
+
+	return events, err
+}
+
+func (r *OutboxPostgres) MarkPublished(eventId int) error {
+	query := fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, error = NULL, published_at = now() WHERE id = $1",
+		outboxTable)
+	_, err := r.db.Exec(query, eventId)
+
+	return err
+}
+
+// Fail records a failed attempt to publish the event, which is retried at nextAttemptAt.
+func (r *OutboxPostgres) Fail(eventId int, message string, nextAttemptAt time.Time) error {
+	query := fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, error = $1, next_attempt_at = $2 WHERE id = $3",
+		outboxTable)
+	_, err := r.db.Exec(query, message, nextAttemptAt, eventId)
+
+	return err
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	"github.com/zhashkevych/todo-app"
+	"testing"
+	"time"
+)
+
+func TestOutboxPostgres_Add(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewOutboxPostgres(db)
+
+	payload := json.RawMessage(`{"event":"item.created"}`)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		input   todo.OutboxEvent
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs("item.created", 1, 2, []byte(payload)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			input: todo.OutboxEvent{
+				Event:   todo.EventItemCreated,
+				ListId:  1,
+				ItemId:  intPointer(2),
+				Payload: payload,
+			},
+		},
+		{
+			name: "List Event",
+			mock: func() {
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs("list.created", 1, nil, []byte(payload)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			input: todo.OutboxEvent{
+				Event:   todo.EventListCreated,
+				ListId:  1,
+				Payload: payload,
+			},
+		},
+		{
+			name: "Failed Insert",
+			mock: func() {
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs("list.created", 1, nil, []byte(payload)).
+					WillReturnError(errors.New("insert error"))
+			},
+			input: todo.OutboxEvent{
+				Event:   todo.EventListCreated,
+				ListId:  1,
+				Payload: payload,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.Add(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOutboxPostgres_Claim(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewOutboxPostgres(db)
+
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "event", "list_id", "item_id", "payload", "attempts", "created_at"}
+
+	type args struct {
+		limit int
+		lease time.Duration
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    []todo.OutboxEvent
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "list.created", 1, nil, []byte(`{}`), 0, createdAt).
+					AddRow(2, "item.created", 1, 3, []byte(`{}`), 2, createdAt)
+
+				mock.ExpectQuery("WITH due AS \\( SELECT id FROM outbox WHERE published_at IS NULL (.+) FOR UPDATE SKIP LOCKED\\)").
+					WithArgs(100, float64(60)).WillReturnRows(rows)
+			},
+			input: args{
+				limit: 100,
+				lease: time.Minute,
+			},
+			want: []todo.OutboxEvent{
+				{Id: 1, Event: todo.EventListCreated, ListId: 1, Payload: json.RawMessage(`{}`), CreatedAt: createdAt},
+				{Id: 2, Event: todo.EventItemCreated, ListId: 1, ItemId: intPointer(3), Payload: json.RawMessage(`{}`), Attempts: 2, CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "No Events",
+			mock: func() {
+				rows := sqlmock.NewRows(columns)
+
+				mock.ExpectQuery("WITH due AS (.+)").
+					WithArgs(100, float64(60)).WillReturnRows(rows)
+			},
+			input: args{
+				limit: 100,
+				lease: time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.Claim(tt.input.limit, tt.input.lease)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOutboxPostgres_Fail(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewOutboxPostgres(db)
+
+	nextAttemptAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("UPDATE outbox SET attempts = attempts \\+ 1, error = (.+), next_attempt_at = (.+) WHERE id = (.+)").
+		WithArgs("broker unavailable", nextAttemptAt, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = r.Fail(1, "broker unavailable", nextAttemptAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
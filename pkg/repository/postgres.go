@@ -1,16 +1,37 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/zhashkevych/todo-app"
+	"reflect"
+	"strings"
 )
 
 const (
-	usersTable      = "users"
-	todoListsTable  = "todo_lists"
-	usersListsTable = "users_lists"
-	todoItemsTable  = "todo_items"
-	listsItemsTable = "lists_items"
+	usersTable             = "users"
+	todoListsTable         = "todo_lists"
+	usersListsTable        = "users_lists"
+	todoItemsTable         = "todo_items"
+	listsItemsTable        = "lists_items"
+	tagsTable              = "tags"
+	itemsTagsTable         = "items_tags"
+	idempotencyKeysTable   = "idempotency_keys"
+	activityLogTable       = "activity_log"
+	webhooksTable          = "webhooks"
+	webhookDeliveriesTable = "webhook_deliveries"
+	outboxTable            = "outbox"
+)
+
+// Columns selected into todo.TodoList from todo_lists as tl
+// and into todo.TodoItem from todo_items as ti joined with lists_items as li.
+const (
+	todoListColumns = "tl.id, tl.title, tl.description, tl.position, tl.is_template, tl.archived, tl.deleted_at, " +
+		"tl.created_at, tl.updated_at, tl.created_by, tl.updated_by, tl.version"
+	todoItemColumns = "ti.id, ti.title, ti.description, ti.done, li.list_id, ti.parent_id, ti.position, ti.archived, ti.deleted_at, " +
+		"ti.created_at, ti.updated_at, ti.created_by, ti.updated_by, ti.completed_at, ti.version, " +
+		"to_char(ti.due_date, 'YYYY-MM-DD') AS due_date"
 )
 
 type Config struct {
@@ -22,9 +43,77 @@ type Config struct {
 	SSLMode  string
 }
 
+// Database is implemented by *sqlx.DB and by the transactions repositories work within.
+type Database interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+}
+
+type transaction interface {
+	Database
+	Commit() error
+	Rollback() error
+}
+
+// savepoint is a transaction nested into another one. Every nesting level has a savepoint
+// of its own name, as Postgres resolves a name to the most recent savepoint with it.
+type savepoint struct {
+	*sqlx.Tx
+	depth int
+}
+
+func (s savepoint) name() string {
+	return fmt.Sprintf("nested_%d", s.depth)
+}
+
+func (s savepoint) Commit() error {
+	_, err := s.Exec("RELEASE SAVEPOINT " + s.name())
+	return err
+}
+
+// Rollback discards the changes made since the savepoint and releases it,
+// Postgres keeps the savepoint after rolling back to it.
+func (s savepoint) Rollback() error {
+	if _, err := s.Exec("ROLLBACK TO SAVEPOINT " + s.name()); err != nil {
+		return err
+	}
+
+	_, err := s.Exec("RELEASE SAVEPOINT " + s.name())
+	return err
+}
+
+// begin starts a transaction on db. When db already is a transaction,
+// a savepoint is started instead.
+func begin(db Database) (transaction, error) {
+	var tx *sqlx.Tx
+	depth := 1
+	switch db := db.(type) {
+	case *sqlx.DB:
+		tx, err := db.Beginx()
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	case *sqlx.Tx:
+		tx = db
+	case savepoint:
+		tx, depth = db.Tx, db.depth+1
+	default:
+		return nil, fmt.Errorf("can't begin transaction on %T", db)
+	}
+
+	sp := savepoint{Tx: tx, depth: depth}
+	if _, err := tx.Exec("SAVEPOINT " + sp.name()); err != nil {
+		return nil, err
+	}
+
+	return sp, nil
+}
+
 func NewPostgresDB(cfg Config) (*sqlx.DB, error) {
-	db, err := sqlx.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.Username, cfg.DBName, cfg.Password, cfg.SSLMode))
+	db, err := sqlx.Open("postgres", dataSourceName(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -36,3 +125,68 @@ func NewPostgresDB(cfg Config) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+func dataSourceName(cfg Config) string {
+	return fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.Username, cfg.DBName, cfg.Password, cfg.SSLMode)
+}
+
+// orderBy returns the ORDER BY clause for a sort field of a filter on the table alias.
+// Rows are kept in manual order unless a timestamp field is given.
+func orderBy(alias, sort string) string {
+	direction := ""
+	if strings.HasPrefix(sort, "-") {
+		direction = " DESC"
+		sort = strings.TrimPrefix(sort, "-")
+	}
+
+	switch sort {
+	case "created_at", "updated_at":
+		return fmt.Sprintf(" ORDER BY %s.%s%s, %s.id%s", alias, sort, direction, alias, direction)
+	default:
+		return fmt.Sprintf(" ORDER BY %s.position, %s.id", alias, alias)
+	}
+}
+
+// selectPage selects the page of the rows of the query, ordered by order, into dest, a pointer to a slice,
+// and returns the number of rows of the whole query. Only limited pages are counted by a separate query.
+func selectPage(db Database, dest interface{}, query, order string, args []interface{}, page todo.Page) (int, error) {
+	if page.Limit == 0 {
+		if err := db.Select(dest, query+order, args...); err != nil {
+			return 0, err
+		}
+
+		return reflect.ValueOf(dest).Elem().Len(), nil
+	}
+
+	pageQuery := fmt.Sprintf("%s%s LIMIT $%d OFFSET $%d", query, order, len(args)+1, len(args)+2)
+	pageArgs := append(append([]interface{}{}, args...), page.Limit, page.Offset)
+	if err := db.Select(dest, pageQuery, pageArgs...); err != nil {
+		return 0, err
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS page", query)
+	err := db.Get(&total, countQuery, args...)
+
+	return total, err
+}
+
+// checkVersion returns todo.ErrVersionMismatch when a statement conditioned
+// on the given version didn't change any rows.
+func checkVersion(res sql.Result, version *int) error {
+	if version == nil {
+		return nil
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return todo.ErrVersionMismatch
+	}
+
+	return nil
+}
@@ -1,41 +1,149 @@
 package repository
 
 import (
-	"github.com/jmoiron/sqlx"
 	"github.com/zhashkevych/todo-app"
+	"time"
 )
 
 type Authorization interface {
 	CreateUser(user todo.User) (int, error)
 	GetUser(username, password string) (todo.User, error)
+	GetUserById(userId int) (todo.User, error)
 }
 
 type TodoList interface {
 	Create(userId int, list todo.TodoList) (int, error)
-	GetAll(userId int) ([]todo.TodoList, error)
+	CreateWithItems(userId int, list todo.TodoList, items []todo.TodoItem) (int, error)
+	CreateImported(userId int, list todo.TodoList, items []todo.TodoItem, tags map[int][]string) (int, error)
+	GetAll(userId int, filter todo.ListFilter) ([]todo.TodoList, int, error)
 	GetById(userId, listId int) (todo.TodoList, error)
-	Delete(userId, listId int) error
-	Update(userId, listId int, input todo.UpdateListInput) error
+	GetLastPosition(userId int) (string, error)
+	GetPositionAfter(userId int, position string) (string, error)
+	GetPositionBefore(userId int, position string) (string, error)
+	UpdatePosition(userId, listId int, position string) error
+	SetArchived(userId, listId int, archived bool) error
+	Delete(userId, listId int, version *int) error
+	Restore(userId, listId int) error
+	Update(userId, listId int, input todo.UpdateListInput, version *int) error
 }
 
 type TodoItem interface {
-	Create(listId int, item todo.TodoItem) (int, error)
-	GetAll(userId, listId int) ([]todo.TodoItem, error)
+	Create(userId, listId int, item todo.TodoItem) (int, error)
+	CreateChild(userId, parentId int, item todo.TodoItem) (int, error)
+	GetAll(userId, listId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error)
+	GetAllByUser(userId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error)
+	GetAllInList(listId int) ([]todo.TodoItem, error)
+	GetAllByLists(userId int, listIds []int) ([]todo.TodoItem, error)
+	GetChildren(userId, itemId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error)
+	GetTree(listId int) ([]todo.TodoItem, error)
 	GetById(userId, itemId int) (todo.TodoItem, error)
-	Delete(userId, itemId int) error
-	Update(userId, itemId int, input todo.UpdateItemInput) error
+	GetProgress(itemId int) (todo.ItemProgress, error)
+	GetLastPosition(listId int, parentId *int) (string, error)
+	GetPositionAfter(listId int, parentId *int, position string) (string, error)
+	GetPositionBefore(listId int, parentId *int, position string) (string, error)
+	UpdatePosition(userId, itemId int, position string) error
+	SetArchived(userId, itemId int, archived bool) error
+	MoveToList(userId, itemId, listId int, parentId *int, position string) error
+	Copy(userId, itemId, listId int, position string) (int, error)
+	Delete(userId, itemId int, version *int) error
+	Restore(userId, itemId int) error
+	Update(userId, itemId int, input todo.UpdateItemInput, version *int) error
+}
+
+type Tag interface {
+	Create(userId int, tag todo.Tag) (int, error)
+	GetAll(userId int) ([]todo.Tag, error)
+	GetById(userId, tagId int) (todo.Tag, error)
+	Delete(userId, tagId int) error
+	Update(userId, tagId int, input todo.UpdateTagInput) error
+	GetByItemId(userId, itemId int) ([]todo.Tag, error)
+	GetNamesByList(userId, listId int) (map[int][]string, error)
+	AddToItem(userId, itemId, tagId int) error
+	RemoveFromItem(userId, itemId, tagId int) error
+}
+
+type Trash interface {
+	GetLists(userId int) ([]todo.TodoList, error)
+	GetItems(userId int) ([]todo.TodoItem, error)
+	Purge(before time.Time) error
+}
+
+type Idempotency interface {
+	Reserve(userId int, key, requestHash string, expiredBefore time.Time) (bool, error)
+	Get(userId int, key string) (todo.IdempotencyKey, error)
+	Complete(userId int, key string, statusCode int, headers todo.ResponseHeaders, body []byte) error
+	Release(userId int, key string) error
+}
+
+type Activity interface {
+	Create(activity todo.Activity) error
+	GetAll(userId int, filter todo.ActivityFilter) ([]todo.Activity, error)
+	GetByList(userId, listId int, filter todo.ActivityFilter) ([]todo.Activity, error)
+	GetSince(userId, afterId, limit int) ([]todo.Activity, error)
+	GetLastId(userId int) (int, error)
+	GetListUsers(listId int) ([]int, error)
+	GetOperation(userId int, activityId *int, since time.Time) ([]todo.Activity, error)
+	SetUndone(activityId int) error
+}
+
+type Webhook interface {
+	Create(userId int, webhook todo.Webhook) (int, error)
+	GetAll(userId int) ([]todo.Webhook, error)
+	GetById(userId, webhookId int) (todo.Webhook, error)
+	Delete(userId, webhookId int) error
+	GetDeliveries(userId, webhookId int) ([]todo.WebhookDelivery, error)
+	Enqueue(event string, listId int, payload []byte) error
+	Replay(userId, webhookId, deliveryId int) (int, error)
+	Claim(limit int, lease time.Duration) ([]todo.PendingDelivery, error)
+	Complete(deliveryId, responseStatus int) error
+	Fail(deliveryId int, responseStatus *int, message string, nextAttemptAt *time.Time) error
+}
+
+type Outbox interface {
+	Add(event todo.OutboxEvent) error
+	Claim(limit int, lease time.Duration) ([]todo.OutboxEvent, error)
+	MarkPublished(eventId int) error
+	Fail(eventId int, message string, nextAttemptAt time.Time) error
+}
+
+type Calendar interface {
+	SetToken(userId int, tokenHash *string) error
+	GetUserId(tokenHash string) (int, error)
+	GetDueItems(userId int) ([]todo.TodoItem, error)
+}
+
+// Transactor runs functions with repositories working within a single transaction.
+// Transactions started from such repositories become savepoints of the outer one.
+type Transactor interface {
+	WithinTransaction(fn func(repos *Repository) error) error
 }
 
 type Repository struct {
 	Authorization
 	TodoList
 	TodoItem
+	Tag
+	Trash
+	Idempotency
+	Activity
+	Webhook
+	Outbox
+	Calendar
+	Transactor
 }
 
-func NewRepository(db *sqlx.DB) *Repository {
+func NewRepository(db Database) *Repository {
 	return &Repository{
 		Authorization: NewAuthPostgres(db),
 		TodoList:      NewTodoListPostgres(db),
 		TodoItem:      NewTodoItemPostgres(db),
+		Tag:           NewTagPostgres(db),
+		Trash:         NewTrashPostgres(db),
+		Idempotency:   NewIdempotencyPostgres(db),
+		Activity:      NewActivityPostgres(db),
+		Webhook:       NewWebhookPostgres(db),
+		Outbox:        NewOutboxPostgres(db),
+		Calendar:      NewCalendarPostgres(db),
+		Transactor:    NewTransactorPostgres(db),
 	}
 }
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/zhashkevych/todo-app"
+)
+
+type TagPostgres struct {
+	db Database
+}
+
+func NewTagPostgres(db Database) *TagPostgres {
+	return &TagPostgres{db: db}
+}
+
+func (r *TagPostgres) Create(userId int, tag todo.Tag) (int, error) {
+	var id int
+	query := fmt.Sprintf("INSERT INTO %s (user_id, name) VALUES ($1, $2) RETURNING id", tagsTable)
+
+	row := r.db.QueryRow(query, userId, tag.Name)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *TagPostgres) GetAll(userId int) ([]todo.Tag, error) {
+	var tags []todo.Tag
+	query := fmt.Sprintf("SELECT t.id, t.name FROM %s t WHERE t.user_id = $1", tagsTable)
+	err := r.db.Select(&tags, query, userId)
+
+	return tags, err
+}
+
+func (r *TagPostgres) GetById(userId, tagId int) (todo.Tag, error) {
+	var tag todo.Tag
+	query := fmt.Sprintf("SELECT t.id, t.name FROM %s t WHERE t.user_id = $1 AND t.id = $2", tagsTable)
+	err := r.db.Get(&tag, query, userId, tagId)
+
+	return tag, err
+}
+
+func (r *TagPostgres) Delete(userId, tagId int) error {
+	query := fmt.Sprintf("DELETE FROM %s t WHERE t.user_id = $1 AND t.id = $2", tagsTable)
+	_, err := r.db.Exec(query, userId, tagId)
+
+	return err
+}
+
+// Update renames the tag. Items reference tags by id,
+// so every tagged item picks up the new name.
+func (r *TagPostgres) Update(userId, tagId int, input todo.UpdateTagInput) error {
+	query := fmt.Sprintf("UPDATE %s t SET name = $1 WHERE t.user_id = $2 AND t.id = $3", tagsTable)
+	_, err := r.db.Exec(query, *input.Name, userId, tagId)
+
+	return err
+}
+
+func (r *TagPostgres) GetByItemId(userId, itemId int) ([]todo.Tag, error) {
+	var tags []todo.Tag
+	query := fmt.Sprintf(`SELECT t.id, t.name FROM %s t INNER JOIN %s it on it.tag_id = t.id
+									WHERE t.user_id = $1 AND it.item_id = $2`,
+		tagsTable, itemsTagsTable)
+	err := r.db.Select(&tags, query, userId, itemId)
+
+	return tags, err
+}
+
+// GetNamesByList returns the names of the user's tags on the items of the list that aren't deleted, by item id.
+func (r *TagPostgres) GetNamesByList(userId, listId int) (map[int][]string, error) {
+	var itemTags []struct {
+		ItemId int    `db:"item_id"`
+		Name   string `db:"name"`
+	}
+	query := fmt.Sprintf(`SELECT it.item_id, t.name FROM %s t INNER JOIN %s it on it.tag_id = t.id
+									INNER JOIN %s li on li.item_id = it.item_id INNER JOIN %s ti on ti.id = it.item_id
+									WHERE t.user_id = $1 AND li.list_id = $2 AND ti.deleted_at IS NULL ORDER BY it.item_id, t.name`,
+		tagsTable, itemsTagsTable, listsItemsTable, todoItemsTable)
+	if err := r.db.Select(&itemTags, query, userId, listId); err != nil {
+		return nil, err
+	}
+
+	names := make(map[int][]string)
+	for _, itemTag := range itemTags {
+		names[itemTag.ItemId] = append(names[itemTag.ItemId], itemTag.Name)
+	}
+
+	return names, nil
+}
+
+// AddToItem tags the item, the item is marked as updated by the user if it wasn't tagged yet.
+func (r *TagPostgres) AddToItem(userId, itemId, tagId int) error {
+	query := fmt.Sprintf(`WITH added AS (INSERT INTO %s (item_id, tag_id) VALUES ($2, $3) ON CONFLICT DO NOTHING RETURNING item_id)
+								UPDATE %s SET updated_at = now(), updated_by = $1 WHERE id IN (SELECT item_id FROM added)`,
+		itemsTagsTable, todoItemsTable)
+	_, err := r.db.Exec(query, userId, itemId, tagId)
+
+	return err
+}
+
+// RemoveFromItem untags the item, the item is marked as updated by the user if it was tagged.
+func (r *TagPostgres) RemoveFromItem(userId, itemId, tagId int) error {
+	query := fmt.Sprintf(`WITH removed AS (DELETE FROM %s it USING %s t
+									WHERE it.tag_id = t.id AND t.user_id = $1 AND it.item_id = $2 AND it.tag_id = $3 RETURNING it.item_id)
+								UPDATE %s SET updated_at = now(), updated_by = $1 WHERE id IN (SELECT item_id FROM removed)`,
+		itemsTagsTable, tagsTable, todoItemsTable)
+	_, err := r.db.Exec(query, userId, itemId, tagId)
+
+	return err
+}
@@ -0,0 +1,358 @@
+package repository
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	"github.com/zhashkevych/todo-app"
+	"testing"
+)
+
+func TestTagPostgres_Create(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTagPostgres(db)
+
+	type args struct {
+		userId int
+		tag    todo.Tag
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+				mock.ExpectQuery("INSERT INTO tags").
+					WithArgs(1, "urgent").WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				tag:    todo.Tag{Name: "urgent"},
+			},
+			want: 1,
+		},
+		{
+			name: "Duplicate Name",
+			mock: func() {
+				mock.ExpectQuery("INSERT INTO tags").
+					WithArgs(1, "urgent").WillReturnError(errors.New("duplicate key value"))
+			},
+			input: args{
+				userId: 1,
+				tag:    todo.Tag{Name: "urgent"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.Create(tt.input.userId, tt.input.tag)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagPostgres_GetByItemId(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTagPostgres(db)
+
+	type args struct {
+		userId int
+		itemId int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    []todo.Tag
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "name"}).
+					AddRow(1, "urgent").
+					AddRow(2, "home")
+
+				mock.ExpectQuery("SELECT (.+) FROM tags t INNER JOIN items_tags it on (.+) WHERE (.+)").
+					WithArgs(1, 2).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+			want: []todo.Tag{
+				{Id: 1, Name: "urgent"},
+				{Id: 2, Name: "home"},
+			},
+		},
+		{
+			name: "No Records",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "name"})
+
+				mock.ExpectQuery("SELECT (.+) FROM tags t INNER JOIN items_tags it on (.+) WHERE (.+)").
+					WithArgs(1, 2).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				itemId: 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetByItemId(tt.input.userId, tt.input.itemId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagPostgres_GetNamesByList(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTagPostgres(db)
+
+	type args struct {
+		userId int
+		listId int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    map[int][]string
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"item_id", "name"}).
+					AddRow(2, "home").
+					AddRow(2, "urgent").
+					AddRow(3, "home")
+
+				mock.ExpectQuery("SELECT (.+) FROM tags t INNER JOIN items_tags it on (.+) INNER JOIN lists_items li on (.+) WHERE (.+) AND ti.deleted_at IS NULL").
+					WithArgs(1, 1).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				listId: 1,
+			},
+			want: map[int][]string{2: {"home", "urgent"}, 3: {"home"}},
+		},
+		{
+			name: "No Records",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"item_id", "name"})
+
+				mock.ExpectQuery("SELECT (.+) FROM tags t INNER JOIN items_tags it on (.+) WHERE (.+)").
+					WithArgs(1, 1).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				listId: 1,
+			},
+			want: map[int][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetNamesByList(tt.input.userId, tt.input.listId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagPostgres_Update(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTagPostgres(db)
+
+	type args struct {
+		userId int
+		tagId  int
+		input  todo.UpdateTagInput
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectExec("UPDATE tags t SET (.+) WHERE (.+)").
+					WithArgs("backend", 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId: 1,
+				tagId:  2,
+				input:  todo.UpdateTagInput{Name: stringPointer("backend")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.Update(tt.input.userId, tt.input.tagId, tt.input.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagPostgres_AddToItem(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTagPostgres(db)
+
+	type args struct {
+		userId int
+		itemId int
+		tagId  int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectExec("WITH added AS \\(INSERT INTO items_tags (.+) ON CONFLICT DO NOTHING RETURNING item_id\\) UPDATE todo_items SET updated_at = now\\(\\), updated_by = \\$1 (.+)").
+					WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId: 1,
+				itemId: 2,
+				tagId:  3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.AddToItem(tt.input.userId, tt.input.itemId, tt.input.tagId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTagPostgres_RemoveFromItem(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTagPostgres(db)
+
+	type args struct {
+		userId int
+		itemId int
+		tagId  int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectExec("WITH removed AS \\(DELETE FROM items_tags it USING tags t WHERE (.+) RETURNING it.item_id\\) UPDATE todo_items SET updated_at = now\\(\\), updated_by = \\$1 (.+)").
+					WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				userId: 1,
+				itemId: 2,
+				tagId:  3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.RemoveFromItem(tt.input.userId, tt.input.itemId, tt.input.tagId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
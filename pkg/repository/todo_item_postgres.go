@@ -1,30 +1,33 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
-	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/zhashkevych/todo-app"
 	"strings"
+	"time"
 )
 
 type TodoItemPostgres struct {
-	db *sqlx.DB
+	db Database
 }
 
-func NewTodoItemPostgres(db *sqlx.DB) *TodoItemPostgres {
+func NewTodoItemPostgres(db Database) *TodoItemPostgres {
 	return &TodoItemPostgres{db: db}
 }
 
-func (r *TodoItemPostgres) Create(listId int, item todo.TodoItem) (int, error) {
-	tx, err := r.db.Begin()
+func (r *TodoItemPostgres) Create(userId, listId int, item todo.TodoItem) (int, error) {
+	tx, err := begin(r.db)
 	if err != nil {
 		return 0, err
 	}
 
 	var itemId int
-	createItemQuery := fmt.Sprintf("INSERT INTO %s (title, description) values ($1, $2) RETURNING id", todoItemsTable)
+	createItemQuery := fmt.Sprintf("INSERT INTO %s (title, description, due_date, position, created_by, updated_by) values ($1, $2, $3, $4, $5, $5) RETURNING id",
+		todoItemsTable)
 
-	row := tx.QueryRow(createItemQuery, item.Title, item.Description)
+	row := tx.QueryRow(createItemQuery, item.Title, item.Description, item.DueDate, item.Position, userId)
 	err = row.Scan(&itemId)
 	if err != nil {
 		tx.Rollback()
@@ -41,12 +44,135 @@ func (r *TodoItemPostgres) Create(listId int, item todo.TodoItem) (int, error) {
 	return itemId, tx.Commit()
 }
 
-func (r *TodoItemPostgres) GetAll(userId, listId int) ([]todo.TodoItem, error) {
+// CreateChild adds a subtask to the parent item, placing it in the parent's list.
+func (r *TodoItemPostgres) CreateChild(userId, parentId int, item todo.TodoItem) (int, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var itemId int
+	createItemQuery := fmt.Sprintf("INSERT INTO %s (title, description, due_date, parent_id, position, created_by, updated_by) values ($1, $2, $3, $4, $5, $6, $6) RETURNING id",
+		todoItemsTable)
+
+	row := tx.QueryRow(createItemQuery, item.Title, item.Description, item.DueDate, parentId, item.Position, userId)
+	err = row.Scan(&itemId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id) SELECT list_id, $1 FROM %s WHERE item_id = $2", listsItemsTable, listsItemsTable)
+	_, err = tx.Exec(createListItemsQuery, itemId, parentId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return itemId, tx.Commit()
+}
+
+// GetAll returns the page of the root items of the list selected by the filter and the number of all of them.
+func (r *TodoItemPostgres) GetAll(userId, listId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
 	var items []todo.TodoItem
-	query := fmt.Sprintf(`SELECT ti.id, ti.title, ti.description, ti.done FROM %s ti INNER JOIN %s li on li.item_id = ti.id
-									INNER JOIN %s ul on ul.list_id = li.list_id WHERE li.list_id = $1 AND ul.user_id = $2`,
-		todoItemsTable, listsItemsTable, usersListsTable)
-	if err := r.db.Select(&items, query, listId, userId); err != nil {
+	query := fmt.Sprintf(`SELECT %s FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id WHERE li.list_id = $1 AND ul.user_id = $2 AND ti.parent_id IS NULL AND ti.deleted_at IS NULL`,
+		todoItemColumns, todoItemsTable, listsItemsTable, usersListsTable)
+	query, args := applyItemFilter(query, []interface{}{listId, userId}, filter)
+
+	total, err := selectPage(r.db, &items, query, orderBy("ti", filter.Sort), args, filter.Page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// GetAllInList returns every item of the list that isn't deleted, including subtasks and archived items.
+func (r *TodoItemPostgres) GetAllInList(listId int) ([]todo.TodoItem, error) {
+	var items []todo.TodoItem
+	query := fmt.Sprintf(`SELECT %s FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									WHERE li.list_id = $1 AND ti.deleted_at IS NULL ORDER BY ti.position, ti.id`,
+		todoItemColumns, todoItemsTable, listsItemsTable)
+	if err := r.db.Select(&items, query, listId); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetAllByLists returns every item of the user's lists that isn't deleted, including subtasks
+// and archived items, ordered by list and position.
+func (r *TodoItemPostgres) GetAllByLists(userId int, listIds []int) ([]todo.TodoItem, error) {
+	var items []todo.TodoItem
+	query := fmt.Sprintf(`SELECT %s FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id WHERE ul.user_id = $1 AND li.list_id = ANY($2)
+									AND ti.deleted_at IS NULL ORDER BY li.list_id, ti.position, ti.id`,
+		todoItemColumns, todoItemsTable, listsItemsTable, usersListsTable)
+	if err := r.db.Select(&items, query, userId, pq.Array(listIds)); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetAllByUser returns the page of the items of the user's lists selected by the filter and the number of all of them.
+func (r *TodoItemPostgres) GetAllByUser(userId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
+	var items []todo.TodoItem
+	query := fmt.Sprintf(`SELECT %s FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id WHERE ul.user_id = $1 AND ti.deleted_at IS NULL`,
+		todoItemColumns, todoItemsTable, listsItemsTable, usersListsTable)
+	query, args := applyItemFilter(query, []interface{}{userId}, filter)
+	order := " ORDER BY li.list_id, ti.position, ti.id"
+	if filter.Sort != "" {
+		order = orderBy("ti", filter.Sort)
+	}
+
+	total, err := selectPage(r.db, &items, query, order, args, filter.Page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// GetChildren returns the page of the subtasks of the item selected by the filter and the number of all of them.
+func (r *TodoItemPostgres) GetChildren(userId, itemId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
+	var items []todo.TodoItem
+	query := fmt.Sprintf(`SELECT %s FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id WHERE ti.parent_id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL`,
+		todoItemColumns, todoItemsTable, listsItemsTable, usersListsTable)
+	query, args := applyItemFilter(query, []interface{}{itemId, userId}, filter)
+
+	total, err := selectPage(r.db, &items, query, orderBy("ti", filter.Sort), args, filter.Page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// GetProgress counts the subtasks of the item, archived ones don't count.
+func (r *TodoItemPostgres) GetProgress(itemId int) (todo.ItemProgress, error) {
+	var progress todo.ItemProgress
+	query := fmt.Sprintf("SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE done) AS done FROM %s WHERE parent_id = $1 AND deleted_at IS NULL AND NOT archived", todoItemsTable)
+	err := r.db.Get(&progress, query, itemId)
+
+	return progress, err
+}
+
+// GetTree returns all items of the list with parents preceding their subtasks.
+func (r *TodoItemPostgres) GetTree(listId int) ([]todo.TodoItem, error) {
+	var items []todo.TodoItem
+	query := fmt.Sprintf(`WITH RECURSIVE tree AS (
+									SELECT ti.id, ti.title, ti.description, ti.done, ti.completed_at, ti.due_date, ti.parent_id, ti.position, 0 AS depth
+									FROM %s ti INNER JOIN %s li on li.item_id = ti.id WHERE li.list_id = $1 AND ti.parent_id IS NULL AND ti.deleted_at IS NULL
+									UNION ALL
+									SELECT ti.id, ti.title, ti.description, ti.done, ti.completed_at, ti.due_date, ti.parent_id, ti.position, t.depth + 1
+									FROM %s ti INNER JOIN tree t on ti.parent_id = t.id WHERE ti.deleted_at IS NULL)
+								SELECT id, title, description, done, completed_at, due_date, parent_id, position FROM tree ORDER BY depth, position, id`,
+		todoItemsTable, listsItemsTable, todoItemsTable)
+	if err := r.db.Select(&items, query, listId); err != nil {
 		return nil, err
 	}
 
@@ -55,9 +181,9 @@ func (r *TodoItemPostgres) GetAll(userId, listId int) ([]todo.TodoItem, error) {
 
 func (r *TodoItemPostgres) GetById(userId, itemId int) (todo.TodoItem, error) {
 	var item todo.TodoItem
-	query := fmt.Sprintf(`SELECT ti.id, ti.title, ti.description, ti.done FROM %s ti INNER JOIN %s li on li.item_id = ti.id
-									INNER JOIN %s ul on ul.list_id = li.list_id WHERE ti.id = $1 AND ul.user_id = $2`,
-		todoItemsTable, listsItemsTable, usersListsTable)
+	query := fmt.Sprintf(`SELECT %s FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id WHERE ti.id = $1 AND ul.user_id = $2 AND ti.deleted_at IS NULL`,
+		todoItemColumns, todoItemsTable, listsItemsTable, usersListsTable)
 	if err := r.db.Get(&item, query, itemId, userId); err != nil {
 		return item, err
 	}
@@ -65,15 +191,191 @@ func (r *TodoItemPostgres) GetById(userId, itemId int) (todo.TodoItem, error) {
 	return item, nil
 }
 
-func (r *TodoItemPostgres) Delete(userId, itemId int) error {
-	query := fmt.Sprintf(`DELETE FROM %s ti USING %s li, %s ul 
-									WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $1 AND ti.id = $2`,
-									todoItemsTable, listsItemsTable, usersListsTable)
-	_, err := r.db.Exec(query, userId, itemId)
+// GetLastPosition returns the position of the last item among the list's items
+// with the given parent or an empty string if there are none.
+func (r *TodoItemPostgres) GetLastPosition(listId int, parentId *int) (string, error) {
+	var position string
+	query := fmt.Sprintf(`SELECT COALESCE(MAX(ti.position), '') FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									WHERE li.list_id = $1 AND ti.parent_id IS NOT DISTINCT FROM $2`,
+		todoItemsTable, listsItemsTable)
+	err := r.db.Get(&position, query, listId, parentId)
+
+	return position, err
+}
+
+// GetPositionAfter returns the closest sibling position after the given one or an empty string if there are none.
+func (r *TodoItemPostgres) GetPositionAfter(listId int, parentId *int, position string) (string, error) {
+	var next string
+	query := fmt.Sprintf(`SELECT COALESCE(MIN(ti.position), '') FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									WHERE li.list_id = $1 AND ti.parent_id IS NOT DISTINCT FROM $2 AND ti.position > $3`,
+		todoItemsTable, listsItemsTable)
+	err := r.db.Get(&next, query, listId, parentId, position)
+
+	return next, err
+}
+
+// GetPositionBefore returns the closest sibling position before the given one or an empty string if there are none.
+func (r *TodoItemPostgres) GetPositionBefore(listId int, parentId *int, position string) (string, error) {
+	var prev string
+	query := fmt.Sprintf(`SELECT COALESCE(MAX(ti.position), '') FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									WHERE li.list_id = $1 AND ti.parent_id IS NOT DISTINCT FROM $2 AND ti.position < $3`,
+		todoItemsTable, listsItemsTable)
+	err := r.db.Get(&prev, query, listId, parentId, position)
+
+	return prev, err
+}
+
+func (r *TodoItemPostgres) UpdatePosition(userId, itemId int, position string) error {
+	query := fmt.Sprintf(`UPDATE %s ti SET position = $1, updated_at = now(), updated_by = $2, version = ti.version + 1 FROM %s li, %s ul
+									WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $2 AND ti.id = $3`,
+		todoItemsTable, listsItemsTable, usersListsTable)
+	_, err := r.db.Exec(query, position, userId, itemId)
+
+	return err
+}
+
+// MoveToList moves the item with all its subtasks to the list, under the parent
+// or to the root of the list if parentId is nil.
+func (r *TodoItemPostgres) MoveToList(userId, itemId, listId int, parentId *int, position string) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+
+	moveListItemsQuery := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT id FROM %s WHERE id = $1
+									UNION ALL
+									SELECT ti.id FROM %s ti INNER JOIN subtree st on ti.parent_id = st.id)
+								UPDATE %s SET list_id = $2 WHERE item_id IN (SELECT id FROM subtree)`,
+		todoItemsTable, todoItemsTable, listsItemsTable)
+	_, err = tx.Exec(moveListItemsQuery, itemId, listId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	updateItemQuery := fmt.Sprintf(`UPDATE %s SET parent_id = $1, position = $2, updated_at = now(), updated_by = $3, version = version + 1
+								WHERE id = $4`, todoItemsTable)
+	_, err = tx.Exec(updateItemQuery, parentId, position, userId, itemId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Copy copies the item with its subtasks and tags to the root of the list
+// and returns the id of the copy.
+func (r *TodoItemPostgres) Copy(userId, itemId, listId int, position string) (int, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var items []todo.TodoItem
+	query := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT id, title, description, done, completed_at, due_date, parent_id, position, 0 AS depth FROM %s WHERE id = $1 AND deleted_at IS NULL
+									UNION ALL
+									SELECT ti.id, ti.title, ti.description, ti.done, ti.completed_at, ti.due_date, ti.parent_id, ti.position, st.depth + 1
+									FROM %s ti INNER JOIN subtree st on ti.parent_id = st.id WHERE ti.deleted_at IS NULL)
+								SELECT id, title, description, done, completed_at, due_date, parent_id, position FROM subtree ORDER BY depth`,
+		todoItemsTable, todoItemsTable)
+	if err := tx.Select(&items, query, itemId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if len(items) == 0 {
+		tx.Rollback()
+		return 0, sql.ErrNoRows
+	}
+	items[0].Position = position
+
+	ids, err := copyItems(tx, userId, listId, items)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return ids[itemId], tx.Commit()
+}
+
+func (r *TodoItemPostgres) SetArchived(userId, itemId int, archived bool) error {
+	query := fmt.Sprintf(`UPDATE %s ti SET archived = $1, updated_at = now(), updated_by = $2, version = ti.version + 1 FROM %s li, %s ul
+									WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $2 AND ti.id = $3`,
+		todoItemsTable, listsItemsTable, usersListsTable)
+	_, err := r.db.Exec(query, archived, userId, itemId)
+
 	return err
 }
 
-func (r *TodoItemPostgres) Update(userId, itemId int, input todo.UpdateItemInput) error {
+// Delete moves the item with its subtasks to the trash. If version is not nil,
+// the item is deleted only if it still has that version.
+func (r *TodoItemPostgres) Delete(userId, itemId int, version *int) error {
+	args := []interface{}{userId, itemId}
+	versionCond := ""
+	if version != nil {
+		versionCond = " AND ti.version = $3 AND ti.deleted_at IS NULL"
+		args = append(args, *version)
+	}
+
+	query := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT ti.id FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id WHERE ul.user_id = $1 AND ti.id = $2%s
+									UNION ALL
+									SELECT ti.id FROM %s ti INNER JOIN subtree st on ti.parent_id = st.id)
+								UPDATE %s SET deleted_at = now(), updated_at = now(), updated_by = $1, version = version + 1
+								WHERE id IN (SELECT id FROM subtree) AND deleted_at IS NULL`,
+		todoItemsTable, listsItemsTable, usersListsTable, versionCond, todoItemsTable, todoItemsTable)
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return checkVersion(res, version)
+}
+
+// Restore takes the item out of the trash together with the subtasks that were deleted along with it.
+// Items whose list or parent item is still in the trash can't be restored on their own.
+func (r *TodoItemPostgres) Restore(userId, itemId int) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+
+	var deletedAt time.Time
+	getItemQuery := fmt.Sprintf(`SELECT ti.deleted_at FROM %s ti INNER JOIN %s li on li.item_id = ti.id
+									INNER JOIN %s ul on ul.list_id = li.list_id INNER JOIN %s tl on tl.id = li.list_id
+									LEFT JOIN %s p on p.id = ti.parent_id
+									WHERE ul.user_id = $1 AND ti.id = $2 AND ti.deleted_at IS NOT NULL
+									AND tl.deleted_at IS NULL AND p.deleted_at IS NULL FOR UPDATE OF ti`,
+		todoItemsTable, listsItemsTable, usersListsTable, todoListsTable, todoItemsTable)
+	row := tx.QueryRow(getItemQuery, userId, itemId)
+	if err := row.Scan(&deletedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	restoreQuery := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT id FROM %s WHERE id = $1
+									UNION ALL
+									SELECT ti.id FROM %s ti INNER JOIN subtree st on ti.parent_id = st.id)
+								UPDATE %s SET deleted_at = NULL, updated_at = now(), updated_by = $3, version = version + 1
+								WHERE id IN (SELECT id FROM subtree) AND deleted_at = $2`,
+		todoItemsTable, todoItemsTable, todoItemsTable)
+	_, err = tx.Exec(restoreQuery, itemId, deletedAt, userId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update applies the input to the item. If version is not nil,
+// the item is updated only if it still has that version.
+func (r *TodoItemPostgres) Update(userId, itemId int, input todo.UpdateItemInput, version *int) error {
 	setValues := make([]string, 0)
 	args := make([]interface{}, 0)
 	argId := 1
@@ -91,18 +393,138 @@ func (r *TodoItemPostgres) Update(userId, itemId int, input todo.UpdateItemInput
 	}
 
 	if input.Done != nil {
-		setValues = append(setValues, fmt.Sprintf("done=$%d", argId))
+		setValues = append(setValues, fmt.Sprintf("done=$%d, completed_at=CASE WHEN $%d THEN COALESCE(ti.completed_at, now()) END", argId, argId))
 		args = append(args, *input.Done)
 		argId++
 	}
 
+	if input.DueDate != nil {
+		setValues = append(setValues, fmt.Sprintf("due_date=NULLIF($%d, '')::date", argId))
+		args = append(args, *input.DueDate)
+		argId++
+	}
+
+	setValues = append(setValues, fmt.Sprintf("updated_at=now(), updated_by=$%d, version=ti.version+1", argId))
 	setQuery := strings.Join(setValues, ", ")
 
 	query := fmt.Sprintf(`UPDATE %s ti SET %s FROM %s li, %s ul
-									WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $%d AND ti.id = $%d`,
+									WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $%d AND ti.id = $%d AND ti.deleted_at IS NULL`,
 		todoItemsTable, setQuery, listsItemsTable, usersListsTable, argId, argId+1)
 	args = append(args, userId, itemId)
 
-	_, err := r.db.Exec(query, args...)
-	return err
-}
\ No newline at end of file
+	if version != nil {
+		query += fmt.Sprintf(" AND ti.version = $%d", argId+2)
+		args = append(args, *version)
+	}
+
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return checkVersion(res, version)
+}
+
+// applyItemFilter appends the filter conditions to an items query
+// whose WHERE clause references todo_items as ti, lists_items as li and users_lists as ul.
+func applyItemFilter(query string, args []interface{}, filter todo.ItemFilter) (string, []interface{}) {
+	if !filter.IncludeArchived {
+		query += fmt.Sprintf(" AND NOT ti.archived AND NOT EXISTS (SELECT 1 FROM %s tl WHERE tl.id = li.list_id AND tl.archived)",
+			todoListsTable)
+	}
+
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		query += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM %s it INNER JOIN %s t on t.id = it.tag_id
+									WHERE it.item_id = ti.id AND t.user_id = ul.user_id AND t.name = $%d)`,
+			itemsTagsTable, tagsTable, len(args))
+	}
+
+	return query, args
+}
+
+// copyItems inserts copies of the items with their tags into the list and returns
+// the ids of the copies keyed by the original ids. Parents must precede their children,
+// items whose parent is not copied become root items. Copies of done items keep their completion time.
+func copyItems(tx Database, userId, listId int, items []todo.TodoItem) (map[int]int, error) {
+	ids := make(map[int]int, len(items))
+
+	createItemQuery := fmt.Sprintf(`INSERT INTO %s (title, description, done, completed_at, due_date, parent_id, position, created_by, updated_by)
+									values ($1, $2, $3, CASE WHEN $3 THEN COALESCE($4, now()) END, $5, $6, $7, $8, $8) RETURNING id`,
+		todoItemsTable)
+	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id) values ($1, $2)", listsItemsTable)
+	copyTagsQuery := fmt.Sprintf("INSERT INTO %s (item_id, tag_id) SELECT $1, tag_id FROM %s WHERE item_id = $2",
+		itemsTagsTable, itemsTagsTable)
+
+	for _, item := range items {
+		var parentId *int
+		if item.ParentId != nil {
+			if id, ok := ids[*item.ParentId]; ok {
+				parentId = &id
+			}
+		}
+
+		var id int
+		row := tx.QueryRow(createItemQuery, item.Title, item.Description, item.Done, item.CompletedAt, item.DueDate, parentId, item.Position, userId)
+		if err := row.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids[item.Id] = id
+
+		if _, err := tx.Exec(createListItemsQuery, listId, id); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.Exec(copyTagsQuery, id, item.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// importItems inserts items of an import into the list. Unlike copied items,
+// they keep their archived state. They are tagged with the user's tags
+// of the names in tags, which are created if there are none.
+func importItems(tx Database, userId, listId int, items []todo.TodoItem, tags map[int][]string) error {
+	ids := make(map[int]int, len(items))
+
+	createItemQuery := fmt.Sprintf(`INSERT INTO %s (title, description, done, completed_at, archived, due_date, parent_id, position, created_by, updated_by)
+									values ($1, $2, $3, CASE WHEN $3 THEN COALESCE($4, now()) END, $5, $6, $7, $8, $9, $9) RETURNING id`,
+		todoItemsTable)
+	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id) values ($1, $2)", listsItemsTable)
+	// updating the existing tag returns its id
+	tagItemQuery := fmt.Sprintf(`WITH tag AS (INSERT INTO %s (user_id, name) VALUES ($1, $2)
+										ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name RETURNING id)
+									INSERT INTO %s (item_id, tag_id) SELECT $3, id FROM tag ON CONFLICT DO NOTHING`,
+		tagsTable, itemsTagsTable)
+
+	for _, item := range items {
+		var parentId *int
+		if item.ParentId != nil {
+			if id, ok := ids[*item.ParentId]; ok {
+				parentId = &id
+			}
+		}
+
+		var id int
+		row := tx.QueryRow(createItemQuery, item.Title, item.Description, item.Done, item.CompletedAt, item.Archived,
+			item.DueDate, parentId, item.Position, userId)
+		if err := row.Scan(&id); err != nil {
+			return err
+		}
+		ids[item.Id] = id
+
+		if _, err := tx.Exec(createListItemsQuery, listId, id); err != nil {
+			return err
+		}
+
+		for _, name := range tags[item.Id] {
+			if _, err := tx.Exec(tagItemQuery, userId, name, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	sqlmock "github.com/zhashkevych/go-sqlxmock"
 	"github.com/zhashkevych/todo-app"
 	"testing"
+	"time"
 )
 
 func TestTodoItemPostgres_Create(t *testing.T) {
@@ -19,6 +20,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 	r := NewTodoItemPostgres(db)
 
 	type args struct {
+		userId int
 		listId int
 		item   todo.TodoItem
 	}
@@ -34,10 +36,12 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 		{
 			name: "Ok",
 			input: args{
+				userId: 1,
 				listId: 1,
 				item: todo.TodoItem{
 					Title:       "test title",
 					Description: "test description",
+					Position:    "i",
 				},
 			},
 			want: 2,
@@ -46,7 +50,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				mock.ExpectQuery("INSERT INTO todo_items").
-					WithArgs(args.item.Title, args.item.Description).WillReturnRows(rows)
+					WithArgs(args.item.Title, args.item.Description, args.item.DueDate, args.item.Position, args.userId).WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO lists_items").WithArgs(args.listId, id).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -57,10 +61,12 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 		{
 			name: "Empty Fields",
 			input: args{
+				userId: 1,
 				listId: 1,
 				item: todo.TodoItem{
 					Title:       "",
 					Description: "description",
+					Position:    "i",
 				},
 			},
 			mock: func(args args, id int) {
@@ -68,7 +74,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id).RowError(0, errors.New("insert error"))
 				mock.ExpectQuery("INSERT INTO todo_items").
-					WithArgs(args.item.Title, args.item.Description).WillReturnRows(rows)
+					WithArgs(args.item.Title, args.item.Description, args.item.DueDate, args.item.Position, args.userId).WillReturnRows(rows)
 
 				mock.ExpectRollback()
 			},
@@ -77,10 +83,12 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 		{
 			name: "Failed 2nd Insert",
 			input: args{
+				userId: 1,
 				listId: 1,
 				item: todo.TodoItem{
 					Title:       "title",
 					Description: "description",
+					Position:    "i",
 				},
 			},
 			mock: func(args args, id int) {
@@ -88,7 +96,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				mock.ExpectQuery("INSERT INTO todo_items").
-					WithArgs(args.item.Title, args.item.Description).WillReturnRows(rows)
+					WithArgs(args.item.Title, args.item.Description, args.item.DueDate, args.item.Position, args.userId).WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO lists_items").WithArgs(args.listId, id).
 					WillReturnError(errors.New("insert error"))
@@ -103,7 +111,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock(tt.input, tt.want)
 
-			got, err := r.Create(tt.input.listId, tt.input.item)
+			got, err := r.Create(tt.input.userId, tt.input.listId, tt.input.item)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -127,13 +135,15 @@ func TestTodoItemPostgres_GetAll(t *testing.T) {
 	type args struct {
 		listId int
 		userId int
+		filter todo.ItemFilter
 	}
 	tests := []struct {
-		name    string
-		mock    func()
-		input   args
-		want    []todo.TodoItem
-		wantErr bool
+		name      string
+		mock      func()
+		input     args
+		want      []todo.TodoItem
+		wantTotal int
+		wantErr   bool
 	}{
 		{
 			name: "Ok",
@@ -151,10 +161,11 @@ func TestTodoItemPostgres_GetAll(t *testing.T) {
 				userId: 1,
 			},
 			want: []todo.TodoItem{
-				{1, "title1", "description1", true},
-				{2, "title2", "description2", false},
-				{3, "title3", "description3", false},
+				{Id: 1, Title: "title1", Description: "description1", Done: true},
+				{Id: 2, Title: "title2", Description: "description2"},
+				{Id: 3, Title: "title3", Description: "description3"},
 			},
+			wantTotal: 3,
 		},
 		{
 			name: "No Records",
@@ -169,13 +180,142 @@ func TestTodoItemPostgres_GetAll(t *testing.T) {
 				userId: 1,
 			},
 		},
+		{
+			name: "Ok_TagFilter",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).
+					AddRow(1, "title1", "description1", true)
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti INNER JOIN lists_items li on (.+) INNER JOIN users_lists ul on (.+) WHERE (.+) AND EXISTS \\(SELECT 1 FROM items_tags it INNER JOIN tags t on (.+)\\)").
+					WithArgs(1, 1, "urgent").WillReturnRows(rows)
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				filter: todo.ItemFilter{Tag: "urgent"},
+			},
+			want: []todo.TodoItem{
+				{Id: 1, Title: "title1", Description: "description1", Done: true},
+			},
+			wantTotal: 1,
+		},
+		{
+			name: "Ok_Page",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).
+					AddRow(3, "title3", "description3", false)
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti (.+) WHERE (.+) AND EXISTS \\((.+)\\) ORDER BY ti.position, ti.id LIMIT \\$4 OFFSET \\$5").
+					WithArgs(1, 1, "urgent", 2, 2).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM \\(SELECT (.+) FROM todo_items ti (.+)\\) AS page").
+					WithArgs(1, 1, "urgent").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				filter: todo.ItemFilter{Tag: "urgent", Page: todo.Page{Limit: 2, Offset: 2}},
+			},
+			want: []todo.TodoItem{
+				{Id: 3, Title: "title3", Description: "description3"},
+			},
+			wantTotal: 3,
+		},
+		{
+			name: "Count Failure",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"})
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti (.+) LIMIT \\$3 OFFSET \\$4").
+					WithArgs(1, 1, 50, 0).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM (.+) AS page").
+					WithArgs(1, 1).WillReturnError(errors.New("some error"))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				filter: todo.ItemFilter{Page: todo.Page{Limit: 50}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 
-			got, err := r.GetAll(tt.input.userId, tt.input.listId)
+			got, total, err := r.GetAll(tt.input.userId, tt.input.listId, tt.input.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantTotal, total)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_GetAllByLists(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoItemPostgres(db)
+
+	type args struct {
+		userId  int
+		listIds []int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    []todo.TodoItem
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "list_id", "parent_id"}).
+					AddRow(1, "title1", 1, nil).
+					AddRow(2, "title2", 1, 1).
+					AddRow(3, "title3", 2, nil)
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti INNER JOIN lists_items li on (.+) INNER JOIN users_lists ul on (.+) WHERE ul.user_id = (.+) AND li.list_id = ANY\\((.+)\\)").
+					WithArgs(1, "{1,2}").WillReturnRows(rows)
+			},
+			input: args{
+				userId:  1,
+				listIds: []int{1, 2},
+			},
+			want: []todo.TodoItem{
+				{Id: 1, Title: "title1", ListId: 1},
+				{Id: 2, Title: "title2", ListId: 1, ParentId: intPointer(1)},
+				{Id: 3, Title: "title3", ListId: 2},
+			},
+		},
+		{
+			name: "Failed Query",
+			mock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti INNER JOIN lists_items li on (.+) INNER JOIN users_lists ul on (.+) WHERE (.+)").
+					WithArgs(1, "{1}").WillReturnError(errors.New("select error"))
+			},
+			input: args{
+				userId:  1,
+				listIds: []int{1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetAllByLists(tt.input.userId, tt.input.listIds)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -220,7 +360,7 @@ func TestTodoItemPostgres_GetById(t *testing.T) {
 				itemId: 1,
 				userId: 1,
 			},
-			want: todo.TodoItem{1, "title1", "description1", true},
+			want: todo.TodoItem{Id: 1, Title: "title1", Description: "description1", Done: true},
 		},
 		{
 			name: "Not Found",
@@ -264,8 +404,9 @@ func TestTodoItemPostgres_Delete(t *testing.T) {
 	r := NewTodoItemPostgres(db)
 
 	type args struct {
-		itemId int
-		userId int
+		itemId  int
+		userId  int
+		version *int
 	}
 	tests := []struct {
 		name    string
@@ -276,7 +417,7 @@ func TestTodoItemPostgres_Delete(t *testing.T) {
 		{
 			name: "Ok",
 			mock: func() {
-				mock.ExpectExec("DELETE FROM todo_items ti USING lists_items li, users_lists ul WHERE (.+)").
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) UPDATE todo_items SET deleted_at = now\\(\\), updated_at = now\\(\\), updated_by = \\$1, version = version \\+ 1 WHERE (.+)").
 					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			input: args{
@@ -287,7 +428,7 @@ func TestTodoItemPostgres_Delete(t *testing.T) {
 		{
 			name: "Not Found",
 			mock: func() {
-				mock.ExpectExec("DELETE FROM todo_items ti USING lists_items li, users_lists ul WHERE (.+)").
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) UPDATE todo_items SET deleted_at = now\\(\\), updated_at = now\\(\\), updated_by = \\$1, version = version \\+ 1 WHERE (.+)").
 					WithArgs(1, 404).WillReturnError(sql.ErrNoRows)
 			},
 			input: args{
@@ -296,13 +437,26 @@ func TestTodoItemPostgres_Delete(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Version Mismatch",
+			mock: func() {
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) AND ti.version = \\$3 (.+) UPDATE todo_items SET (.+)").
+					WithArgs(1, 1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			input: args{
+				itemId:  1,
+				userId:  1,
+				version: intPointer(2),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 
-			err := r.Delete(tt.input.userId, tt.input.itemId)
+			err := r.Delete(tt.input.userId, tt.input.itemId, tt.input.version)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -323,9 +477,10 @@ func TestTodoItemPostgres_Update(t *testing.T) {
 	r := NewTodoItemPostgres(db)
 
 	type args struct {
-		itemId int
-		userId int
-		input  todo.UpdateItemInput
+		itemId  int
+		userId  int
+		input   todo.UpdateItemInput
+		version *int
 	}
 	tests := []struct {
 		name    string
@@ -381,7 +536,7 @@ func TestTodoItemPostgres_Update(t *testing.T) {
 		{
 			name: "OK_NoInputFields",
 			mock: func() {
-				mock.ExpectExec("UPDATE todo_items ti SET FROM lists_items li, users_lists ul WHERE (.+)").
+				mock.ExpectExec("UPDATE todo_items ti SET updated_at=now\\(\\), updated_by=\\$1, version=ti.version\\+1 FROM lists_items li, users_lists ul WHERE (.+)").
 					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			input: args{
@@ -389,13 +544,29 @@ func TestTodoItemPostgres_Update(t *testing.T) {
 				userId: 1,
 			},
 		},
+		{
+			name: "Version Mismatch",
+			mock: func() {
+				mock.ExpectExec("UPDATE todo_items ti SET (.+) FROM lists_items li, users_lists ul WHERE (.+) AND ti.version = \\$4").
+					WithArgs("new title", 1, 1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				input: todo.UpdateItemInput{
+					Title: stringPointer("new title"),
+				},
+				version: intPointer(2),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 
-			err := r.Update(tt.input.userId, tt.input.itemId, tt.input.input)
+			err := r.Update(tt.input.userId, tt.input.itemId, tt.input.input, tt.input.version)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -413,3 +584,523 @@ func stringPointer(s string) *string {
 func boolPointer(b bool) *bool {
 	return &b
 }
+
+func intPointer(i int) *int {
+	return &i
+}
+
+func TestTodoItemPostgres_CreateChild(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoItemPostgres(db)
+
+	type args struct {
+		userId   int
+		parentId int
+		item     todo.TodoItem
+	}
+	type mockBehavior func(args args, id int)
+
+	tests := []struct {
+		name    string
+		mock    mockBehavior
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			input: args{
+				userId:   1,
+				parentId: 1,
+				item: todo.TodoItem{
+					Title:       "step",
+					Description: "first step",
+					Position:    "i",
+				},
+			},
+			want: 2,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs(args.item.Title, args.item.Description, args.item.DueDate, args.parentId, args.item.Position, args.userId).WillReturnRows(rows)
+
+				mock.ExpectExec("INSERT INTO lists_items \\(list_id, item_id\\) SELECT list_id, (.+) FROM lists_items").
+					WithArgs(id, args.parentId).WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Failed 2nd Insert",
+			input: args{
+				userId:   1,
+				parentId: 1,
+				item: todo.TodoItem{
+					Title:       "step",
+					Description: "first step",
+					Position:    "i",
+				},
+			},
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs(args.item.Title, args.item.Description, args.item.DueDate, args.parentId, args.item.Position, args.userId).WillReturnRows(rows)
+
+				mock.ExpectExec("INSERT INTO lists_items").WithArgs(id, args.parentId).
+					WillReturnError(errors.New("insert error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(tt.input, tt.want)
+
+			got, err := r.CreateChild(tt.input.userId, tt.input.parentId, tt.input.item)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_GetChildren(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoItemPostgres(db)
+
+	type args struct {
+		itemId int
+		userId int
+		filter todo.ItemFilter
+	}
+	tests := []struct {
+		name      string
+		mock      func()
+		input     args
+		want      []todo.TodoItem
+		wantTotal int
+		wantErr   bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).
+					AddRow(2, "step1", "", true).
+					AddRow(3, "step2", "", false)
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti INNER JOIN lists_items li on (.+) WHERE ti.parent_id = \\$1 AND (.+) AND NOT ti.archived (.+) ORDER BY ti.position, ti.id").
+					WithArgs(1, 1).WillReturnRows(rows)
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+			},
+			want: []todo.TodoItem{
+				{Id: 2, Title: "step1", Done: true},
+				{Id: 3, Title: "step2"},
+			},
+			wantTotal: 2,
+		},
+		{
+			name: "Ok_IncludeArchived",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "archived"}).
+					AddRow(2, "step1", "", true, true)
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti INNER JOIN lists_items li on (.+) WHERE ti.parent_id = \\$1 AND ul.user_id = \\$2 AND ti.deleted_at IS NULL ORDER BY ti.position, ti.id").
+					WithArgs(1, 1).WillReturnRows(rows)
+			},
+			input: args{
+				itemId: 1,
+				userId: 1,
+				filter: todo.ItemFilter{IncludeArchived: true},
+			},
+			want: []todo.TodoItem{
+				{Id: 2, Title: "step1", Done: true, Archived: true},
+			},
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, total, err := r.GetChildren(tt.input.userId, tt.input.itemId, tt.input.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantTotal, total)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_GetTree(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoItemPostgres(db)
+
+	completedAt := time.Date(2021, 1, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		input   int
+		want    []todo.TodoItem
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "completed_at", "due_date", "parent_id", "position"}).
+					AddRow(1, "item", "", false, nil, "2021-01-31", nil, "i").
+					AddRow(2, "step", "", true, completedAt, nil, 1, "i")
+
+				mock.ExpectQuery("WITH RECURSIVE tree AS (.+) SELECT id, title, description, done, completed_at, due_date, parent_id, position FROM tree ORDER BY (.+)").
+					WithArgs(1).WillReturnRows(rows)
+			},
+			input: 1,
+			want: []todo.TodoItem{
+				{Id: 1, Title: "item", DueDate: stringPointer("2021-01-31"), Position: "i"},
+				{Id: 2, Title: "step", Done: true, CompletedAt: &completedAt, ParentId: intPointer(1), Position: "i"},
+			},
+		},
+		{
+			name: "Failed Query",
+			mock: func() {
+				mock.ExpectQuery("WITH RECURSIVE tree AS (.+)").
+					WithArgs(1).WillReturnError(errors.New("some error"))
+			},
+			input:   1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetTree(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_GetProgress(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoItemPostgres(db)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		itemId  int
+		want    todo.ItemProgress
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"total", "done"}).AddRow(5, 3)
+
+				mock.ExpectQuery("SELECT COUNT(.+) FROM todo_items WHERE parent_id = (.+) AND NOT archived").
+					WithArgs(1).WillReturnRows(rows)
+			},
+			itemId: 1,
+			want:   todo.ItemProgress{Total: 5, Done: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetProgress(tt.itemId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_GetLastPosition(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoItemPostgres(db)
+
+	parentId := 3
+
+	type args struct {
+		listId   int
+		parentId *int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Ok_RootItems",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow("r")
+				mock.ExpectQuery("SELECT COALESCE\\(MAX\\(ti.position\\), ''\\) FROM todo_items ti INNER JOIN lists_items li on (.+) WHERE (.+)").
+					WithArgs(1, nil).WillReturnRows(rows)
+			},
+			input: args{
+				listId: 1,
+			},
+			want: "r",
+		},
+		{
+			name: "Ok_Subtasks",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow("")
+				mock.ExpectQuery("SELECT COALESCE\\(MAX\\(ti.position\\), ''\\) FROM todo_items ti INNER JOIN lists_items li on (.+) WHERE (.+)").
+					WithArgs(1, &parentId).WillReturnRows(rows)
+			},
+			input: args{
+				listId:   1,
+				parentId: &parentId,
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetLastPosition(tt.input.listId, tt.input.parentId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_MoveToList(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoItemPostgres(db)
+
+	type args struct {
+		userId   int
+		itemId   int
+		listId   int
+		position string
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) UPDATE lists_items SET list_id = (.+)").
+					WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+
+				mock.ExpectExec("UPDATE todo_items SET parent_id = (.+), position = (.+), updated_at = now\\(\\), updated_by = (.+) WHERE (.+)").
+					WithArgs(nil, "r", 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:   1,
+				itemId:   1,
+				listId:   2,
+				position: "r",
+			},
+		},
+		{
+			name: "Failed Update",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("WITH RECURSIVE subtree AS (.+) UPDATE lists_items SET list_id = (.+)").
+					WithArgs(1, 2).WillReturnError(errors.New("update error"))
+
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId:   1,
+				itemId:   1,
+				listId:   2,
+				position: "r",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.MoveToList(tt.input.userId, tt.input.itemId, tt.input.listId, nil, tt.input.position)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_Copy(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoItemPostgres(db)
+
+	type args struct {
+		userId   int
+		itemId   int
+		listId   int
+		position string
+	}
+	completedAt := time.Date(2021, 1, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "completed_at", "due_date", "parent_id", "position"}).
+					AddRow(1, "item", "description", false, nil, "2021-01-31", nil, "i").
+					AddRow(2, "step", "", true, completedAt, nil, 1, "i")
+				mock.ExpectQuery("WITH RECURSIVE subtree AS (.+) SELECT (.+) FROM subtree").
+					WithArgs(1).WillReturnRows(rows)
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("item", "description", false, nil, "2021-01-31", nil, "r", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				mock.ExpectExec("INSERT INTO lists_items").WithArgs(5, 10).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO items_tags \\(item_id, tag_id\\) SELECT (.+) FROM items_tags").WithArgs(10, 1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("step", "", true, completedAt, nil, 10, "i", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+				mock.ExpectExec("INSERT INTO lists_items").WithArgs(5, 11).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO items_tags \\(item_id, tag_id\\) SELECT (.+) FROM items_tags").WithArgs(11, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId:   1,
+				itemId:   1,
+				listId:   5,
+				position: "r",
+			},
+			want: 10,
+		},
+		{
+			name: "Not Found",
+			mock: func() {
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "parent_id", "position"})
+				mock.ExpectQuery("WITH RECURSIVE subtree AS (.+) SELECT (.+) FROM subtree").
+					WithArgs(404).WillReturnRows(rows)
+
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId:   1,
+				itemId:   404,
+				listId:   5,
+				position: "r",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.Copy(tt.input.userId, tt.input.itemId, tt.input.listId, tt.input.position)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,29 +2,30 @@ package repository
 
 import (
 	"fmt"
-	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 	"github.com/zhashkevych/todo-app"
 	"strings"
+	"time"
 )
 
 type TodoListPostgres struct {
-	db *sqlx.DB
+	db Database
 }
 
-func NewTodoListPostgres(db *sqlx.DB) *TodoListPostgres {
+func NewTodoListPostgres(db Database) *TodoListPostgres {
 	return &TodoListPostgres{db: db}
 }
 
 func (r *TodoListPostgres) Create(userId int, list todo.TodoList) (int, error) {
-	tx, err := r.db.Begin()
+	tx, err := begin(r.db)
 	if err != nil {
 		return 0, err
 	}
 
 	var id int
-	createListQuery := fmt.Sprintf("INSERT INTO %s (title, description) VALUES ($1, $2) RETURNING id", todoListsTable)
-	row := tx.QueryRow(createListQuery, list.Title, list.Description)
+	createListQuery := fmt.Sprintf("INSERT INTO %s (title, description, position, is_template, created_by, updated_by) VALUES ($1, $2, $3, $4, $5, $5) RETURNING id",
+		todoListsTable)
+	row := tx.QueryRow(createListQuery, list.Title, list.Description, list.Position, list.IsTemplate, userId)
 	if err := row.Scan(&id); err != nil {
 		tx.Rollback()
 		return 0, err
@@ -40,36 +41,237 @@ func (r *TodoListPostgres) Create(userId int, list todo.TodoList) (int, error) {
 	return id, tx.Commit()
 }
 
-func (r *TodoListPostgres) GetAll(userId int) ([]todo.TodoList, error) {
+// CreateWithItems creates the list together with copies of the given items,
+// ordered so that parents precede their subtasks.
+func (r *TodoListPostgres) CreateWithItems(userId int, list todo.TodoList, items []todo.TodoItem) (int, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	createListQuery := fmt.Sprintf("INSERT INTO %s (title, description, position, is_template, created_by, updated_by) VALUES ($1, $2, $3, $4, $5, $5) RETURNING id",
+		todoListsTable)
+	row := tx.QueryRow(createListQuery, list.Title, list.Description, list.Position, list.IsTemplate, userId)
+	if err := row.Scan(&id); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	createUsersListQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id) VALUES ($1, $2)", usersListsTable)
+	_, err = tx.Exec(createUsersListQuery, userId, id)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if _, err := copyItems(tx, userId, id, items); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// CreateImported creates the list with the items of an import, keeping their state. Items
+// reference their parents by the ids they have in the import, parents have to come first.
+// Tags are the names of the user's tags by those ids, missing tags are created.
+func (r *TodoListPostgres) CreateImported(userId int, list todo.TodoList, items []todo.TodoItem, tags map[int][]string) (int, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	createListQuery := fmt.Sprintf(`INSERT INTO %s (title, description, position, is_template, archived, created_by, updated_by)
+									VALUES ($1, $2, $3, $4, $5, $6, $6) RETURNING id`,
+		todoListsTable)
+	row := tx.QueryRow(createListQuery, list.Title, list.Description, list.Position, list.IsTemplate, list.Archived, userId)
+	if err := row.Scan(&id); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	createUsersListQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id) VALUES ($1, $2)", usersListsTable)
+	_, err = tx.Exec(createUsersListQuery, userId, id)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := importItems(tx, userId, id, items, tags); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// GetAll returns the page of the user's lists selected by the filter and the number of all of them.
+func (r *TodoListPostgres) GetAll(userId int, filter todo.ListFilter) ([]todo.TodoList, int, error) {
 	var lists []todo.TodoList
 
-	query := fmt.Sprintf("SELECT tl.id, tl.title, tl.description FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1",
-		todoListsTable, usersListsTable)
-	err := r.db.Select(&lists, query, userId)
+	query := fmt.Sprintf("SELECT %s FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1 AND tl.deleted_at IS NULL",
+		todoListColumns, todoListsTable, usersListsTable)
+	if !filter.IncludeArchived {
+		query += " AND NOT tl.archived"
+	}
+
+	total, err := selectPage(r.db, &lists, query, orderBy("tl", filter.Sort), []interface{}{userId}, filter.Page)
 
-	return lists, err
+	return lists, total, err
 }
 
 func (r *TodoListPostgres) GetById(userId, listId int) (todo.TodoList, error) {
 	var list todo.TodoList
 
-	query := fmt.Sprintf(`SELECT tl.id, tl.title, tl.description FROM %s tl
-								INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1 AND ul.list_id = $2`,
-		todoListsTable, usersListsTable)
+	query := fmt.Sprintf(`SELECT %s FROM %s tl
+								INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1 AND ul.list_id = $2 AND tl.deleted_at IS NULL`,
+		todoListColumns, todoListsTable, usersListsTable)
 	err := r.db.Get(&list, query, userId, listId)
 
 	return list, err
 }
 
-func (r *TodoListPostgres) Delete(userId, listId int) error {
-	query := fmt.Sprintf("DELETE FROM %s tl USING %s ul WHERE tl.id = ul.list_id AND ul.user_id=$1 AND ul.list_id=$2",
+// GetLastPosition returns the position of the user's last list or an empty string if there are none.
+func (r *TodoListPostgres) GetLastPosition(userId int) (string, error) {
+	var position string
+	query := fmt.Sprintf("SELECT COALESCE(MAX(tl.position), '') FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id WHERE ul.user_id = $1",
+		todoListsTable, usersListsTable)
+	err := r.db.Get(&position, query, userId)
+
+	return position, err
+}
+
+// GetPositionAfter returns the closest position of the user's lists after the given one
+// or an empty string if there are none.
+func (r *TodoListPostgres) GetPositionAfter(userId int, position string) (string, error) {
+	var next string
+	query := fmt.Sprintf(`SELECT COALESCE(MIN(tl.position), '') FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id
+								WHERE ul.user_id = $1 AND tl.position > $2`,
+		todoListsTable, usersListsTable)
+	err := r.db.Get(&next, query, userId, position)
+
+	return next, err
+}
+
+// GetPositionBefore returns the closest position of the user's lists before the given one
+// or an empty string if there are none.
+func (r *TodoListPostgres) GetPositionBefore(userId int, position string) (string, error) {
+	var prev string
+	query := fmt.Sprintf(`SELECT COALESCE(MAX(tl.position), '') FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id
+								WHERE ul.user_id = $1 AND tl.position < $2`,
+		todoListsTable, usersListsTable)
+	err := r.db.Get(&prev, query, userId, position)
+
+	return prev, err
+}
+
+func (r *TodoListPostgres) UpdatePosition(userId, listId int, position string) error {
+	query := fmt.Sprintf(`UPDATE %s tl SET position = $1, updated_at = now(), updated_by = $3, version = tl.version + 1 FROM %s ul
+								WHERE tl.id = ul.list_id AND ul.list_id = $2 AND ul.user_id = $3`,
 		todoListsTable, usersListsTable)
-	_, err := r.db.Exec(query, userId, listId)
+	_, err := r.db.Exec(query, position, listId, userId)
 
 	return err
 }
 
-func (r *TodoListPostgres) Update(userId, listId int, input todo.UpdateListInput) error {
+func (r *TodoListPostgres) SetArchived(userId, listId int, archived bool) error {
+	query := fmt.Sprintf(`UPDATE %s tl SET archived = $1, updated_at = now(), updated_by = $3, version = tl.version + 1 FROM %s ul
+								WHERE tl.id = ul.list_id AND ul.list_id = $2 AND ul.user_id = $3`,
+		todoListsTable, usersListsTable)
+	_, err := r.db.Exec(query, archived, listId, userId)
+
+	return err
+}
+
+// Delete moves the list with its items to the trash. The items get
+// the same deletion time as the list, so they can be restored together.
+// If version is not nil, the list is deleted only if it still has that version.
+func (r *TodoListPostgres) Delete(userId, listId int, version *int) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+
+	deleteListQuery := fmt.Sprintf(`UPDATE %s tl SET deleted_at = now(), updated_at = now(), updated_by = $1, version = tl.version + 1 FROM %s ul
+								WHERE tl.id = ul.list_id AND ul.user_id = $1 AND ul.list_id = $2 AND tl.deleted_at IS NULL`,
+		todoListsTable, usersListsTable)
+	args := []interface{}{userId, listId}
+	if version != nil {
+		deleteListQuery += " AND tl.version = $3"
+		args = append(args, *version)
+	}
+
+	res, err := tx.Exec(deleteListQuery, args...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := checkVersion(res, version); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if deleted, err := res.RowsAffected(); err != nil || deleted == 0 {
+		tx.Rollback()
+		return err
+	}
+
+	deleteItemsQuery := fmt.Sprintf(`UPDATE %s ti SET deleted_at = now(), updated_at = now(), updated_by = $2 FROM %s li
+								WHERE ti.id = li.item_id AND li.list_id = $1 AND ti.deleted_at IS NULL`,
+		todoItemsTable, listsItemsTable)
+	_, err = tx.Exec(deleteItemsQuery, listId, userId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Restore takes the list out of the trash together with the items
+// that were deleted along with it.
+func (r *TodoListPostgres) Restore(userId, listId int) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+
+	var deletedAt time.Time
+	getListQuery := fmt.Sprintf(`SELECT tl.deleted_at FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id
+								WHERE ul.user_id = $1 AND ul.list_id = $2 AND tl.deleted_at IS NOT NULL FOR UPDATE OF tl`,
+		todoListsTable, usersListsTable)
+	row := tx.QueryRow(getListQuery, userId, listId)
+	if err := row.Scan(&deletedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	restoreListQuery := fmt.Sprintf("UPDATE %s SET deleted_at = NULL, updated_at = now(), updated_by = $2, version = version + 1 WHERE id = $1",
+		todoListsTable)
+	_, err = tx.Exec(restoreListQuery, listId, userId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	restoreItemsQuery := fmt.Sprintf(`UPDATE %s ti SET deleted_at = NULL, updated_at = now(), updated_by = $3 FROM %s li
+								WHERE ti.id = li.item_id AND li.list_id = $1 AND ti.deleted_at = $2`,
+		todoItemsTable, listsItemsTable)
+	_, err = tx.Exec(restoreItemsQuery, listId, deletedAt, userId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update applies the input to the list. If version is not nil,
+// the list is updated only if it still has that version.
+func (r *TodoListPostgres) Update(userId, listId int, input todo.UpdateListInput, version *int) error {
 	setValues := make([]string, 0)
 	args := make([]interface{}, 0)
 	argId := 1
@@ -86,18 +288,35 @@ func (r *TodoListPostgres) Update(userId, listId int, input todo.UpdateListInput
 		argId++
 	}
 
+	if input.IsTemplate != nil {
+		setValues = append(setValues, fmt.Sprintf("is_template=$%d", argId))
+		args = append(args, *input.IsTemplate)
+		argId++
+	}
+
+	setValues = append(setValues, fmt.Sprintf("updated_at=now(), updated_by=$%d, version=tl.version+1", argId+1))
+
 	// title=$1
 	// description=$1
 	// title=$1, description=$2
 	setQuery := strings.Join(setValues, ", ")
 
-	query := fmt.Sprintf("UPDATE %s tl SET %s FROM %s ul WHERE tl.id = ul.list_id AND ul.list_id=$%d AND ul.user_id=$%d",
+	query := fmt.Sprintf("UPDATE %s tl SET %s FROM %s ul WHERE tl.id = ul.list_id AND ul.list_id=$%d AND ul.user_id=$%d AND tl.deleted_at IS NULL",
 		todoListsTable, setQuery, usersListsTable, argId, argId+1)
 	args = append(args, listId, userId)
 
+	if version != nil {
+		query += fmt.Sprintf(" AND tl.version=$%d", argId+2)
+		args = append(args, *version)
+	}
+
 	logrus.Debugf("updateQuery: %s", query)
 	logrus.Debugf("args: %s", args)
 
-	_, err := r.db.Exec(query, args...)
-	return err
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return checkVersion(res, version)
 }
\ No newline at end of file
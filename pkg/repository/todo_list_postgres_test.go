@@ -2,10 +2,12 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	sqlmock "github.com/zhashkevych/go-sqlxmock"
 	"github.com/zhashkevych/todo-app"
 	"testing"
+	"time"
 )
 
 func TestTodoListPostgres_Create(t *testing.T) {
@@ -35,7 +37,7 @@ func TestTodoListPostgres_Create(t *testing.T) {
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 				mock.ExpectQuery("INSERT INTO todo_lists").
-					WithArgs("title", "description").WillReturnRows(rows)
+					WithArgs("title", "description", "i", false, 1).WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO users_lists").WithArgs(1, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -47,6 +49,7 @@ func TestTodoListPostgres_Create(t *testing.T) {
 				item: todo.TodoList{
 					Title:       "title",
 					Description: "description",
+					Position:    "i",
 				},
 			},
 			want: 1,
@@ -58,7 +61,7 @@ func TestTodoListPostgres_Create(t *testing.T) {
 
 				rows := sqlmock.NewRows([]string{"id"})
 				mock.ExpectQuery("INSERT INTO todo_lists").
-					WithArgs("", "description").WillReturnRows(rows)
+					WithArgs("", "description", "i", false, 1).WillReturnRows(rows)
 
 				mock.ExpectRollback()
 			},
@@ -67,6 +70,7 @@ func TestTodoListPostgres_Create(t *testing.T) {
 				item: todo.TodoList{
 					Title:       "",
 					Description: "description",
+					Position:    "i",
 				},
 			},
 			wantErr: true,
@@ -100,13 +104,15 @@ func TestTodoListPostgres_GetAll(t *testing.T) {
 
 	type args struct {
 		userId int
+		filter todo.ListFilter
 	}
 	tests := []struct {
-		name    string
-		mock    func()
-		input   args
-		want    []todo.TodoList
-		wantErr bool
+		name      string
+		mock      func()
+		input     args
+		want      []todo.TodoList
+		wantTotal int
+		wantErr   bool
 	}{
 		{
 			name: "Ok",
@@ -123,10 +129,11 @@ func TestTodoListPostgres_GetAll(t *testing.T) {
 				userId: 1,
 			},
 			want: []todo.TodoList{
-				{1, "title1", "description1"},
-				{2, "title2", "description2"},
-				{3, "title3", "description3"},
+				{Id: 1, Title: "title1", Description: "description1"},
+				{Id: 2, Title: "title2", Description: "description2"},
+				{Id: 3, Title: "title3", Description: "description3"},
 			},
+			wantTotal: 3,
 		},
 		{
 			name: "Ok",
@@ -143,10 +150,71 @@ func TestTodoListPostgres_GetAll(t *testing.T) {
 				userId: 1,
 			},
 			want: []todo.TodoList{
-				{1, "title1", "description1"},
-				{2, "title2", "description2"},
-				{3, "title3", "description3"},
+				{Id: 1, Title: "title1", Description: "description1"},
+				{Id: 2, Title: "title2", Description: "description2"},
+				{Id: 3, Title: "title3", Description: "description3"},
+			},
+			wantTotal: 3,
+		},
+		{
+			name: "Ok_IncludeArchived",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "archived"}).
+					AddRow(1, "title1", "description1", false).
+					AddRow(2, "title2", "description2", true)
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_lists tl INNER JOIN users_lists ul on (.+) WHERE ul.user_id = \\$1 AND tl.deleted_at IS NULL ORDER BY (.+)").
+					WithArgs(1).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				filter: todo.ListFilter{IncludeArchived: true},
+			},
+			want: []todo.TodoList{
+				{Id: 1, Title: "title1", Description: "description1"},
+				{Id: 2, Title: "title2", Description: "description2", Archived: true},
 			},
+			wantTotal: 2,
+		},
+		{
+			name: "Ok_SortByUpdatedAt",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description"}).
+					AddRow(2, "title2", "description2").
+					AddRow(1, "title1", "description1")
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_lists tl INNER JOIN users_lists ul on (.+) WHERE (.+) ORDER BY tl.updated_at DESC, tl.id DESC").
+					WithArgs(1).WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				filter: todo.ListFilter{Sort: "-updated_at"},
+			},
+			want: []todo.TodoList{
+				{Id: 2, Title: "title2", Description: "description2"},
+				{Id: 1, Title: "title1", Description: "description1"},
+			},
+			wantTotal: 2,
+		},
+		{
+			name: "Ok_Page",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description"}).
+					AddRow(2, "title2", "description2")
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_lists tl INNER JOIN users_lists ul on (.+) WHERE (.+) ORDER BY tl.position, tl.id LIMIT \\$2 OFFSET \\$3").
+					WithArgs(1, 1, 1).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM \\(SELECT (.+) FROM todo_lists tl (.+) AND NOT tl.archived\\) AS page").
+					WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			input: args{
+				userId: 1,
+				filter: todo.ListFilter{Page: todo.Page{Limit: 1, Offset: 1}},
+			},
+			want: []todo.TodoList{
+				{Id: 2, Title: "title2", Description: "description2"},
+			},
+			wantTotal: 3,
 		},
 	}
 
@@ -154,12 +222,13 @@ func TestTodoListPostgres_GetAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 
-			got, err := r.GetAll(tt.input.userId)
+			got, total, err := r.GetAll(tt.input.userId, tt.input.filter)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantTotal, total)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
@@ -199,7 +268,7 @@ func TestTodoListPostgres_GetById(t *testing.T) {
 				listId: 1,
 				userId: 1,
 			},
-			want: todo.TodoList{1, "title1", "description1"},
+			want: todo.TodoList{Id: 1, Title: "title1", Description: "description1"},
 		},
 		{
 			name: "Not Found",
@@ -243,8 +312,9 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 	r := NewTodoListPostgres(db)
 
 	type args struct {
-		listId int
-		userId int
+		listId  int
+		userId  int
+		version *int
 	}
 	tests := []struct {
 		name    string
@@ -255,8 +325,30 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 		{
 			name: "Ok",
 			mock: func() {
-				mock.ExpectExec("DELETE FROM todo_lists tl USING users_lists ul WHERE (.+)").
+				mock.ExpectBegin()
+
+				mock.ExpectExec("UPDATE todo_lists tl SET deleted_at = now\\(\\), updated_at = now\\(\\), updated_by = \\$1, version = tl.version \\+ 1 FROM users_lists ul WHERE (.+)").
 					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec("UPDATE todo_items ti SET deleted_at = now\\(\\), updated_at = now\\(\\), updated_by = \\$2 FROM lists_items li WHERE (.+)").
+					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 3))
+
+				mock.ExpectCommit()
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+			},
+		},
+		{
+			name: "Already Deleted",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("UPDATE todo_lists tl SET deleted_at = now\\(\\), updated_at = now\\(\\), updated_by = \\$1, version = tl.version \\+ 1 FROM users_lists ul WHERE (.+)").
+					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectRollback()
 			},
 			input: args{
 				listId: 1,
@@ -266,8 +358,12 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 		{
 			name: "Not Found",
 			mock: func() {
-				mock.ExpectExec("DELETE FROM todo_lists tl USING users_lists ul WHERE (.+)").
+				mock.ExpectBegin()
+
+				mock.ExpectExec("UPDATE todo_lists tl SET deleted_at = now\\(\\), updated_at = now\\(\\), updated_by = \\$1, version = tl.version \\+ 1 FROM users_lists ul WHERE (.+)").
 					WithArgs(1, 404).WillReturnError(sql.ErrNoRows)
+
+				mock.ExpectRollback()
 			},
 			input: args{
 				listId: 404,
@@ -275,13 +371,30 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Version Mismatch",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("UPDATE todo_lists tl SET (.+) FROM users_lists ul WHERE (.+) AND tl.version = \\$3").
+					WithArgs(1, 1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectRollback()
+			},
+			input: args{
+				listId:  1,
+				userId:  1,
+				version: intPointer(2),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 
-			err := r.Delete(tt.input.userId, tt.input.listId)
+			err := r.Delete(tt.input.userId, tt.input.listId, tt.input.version)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -302,9 +415,10 @@ func TestTodoListPostgres_Update(t *testing.T) {
 	r := NewTodoListPostgres(db)
 
 	type args struct {
-		listId int
-		userId int
-		input  todo.UpdateListInput
+		listId  int
+		userId  int
+		input   todo.UpdateListInput
+		version *int
 	}
 	tests := []struct {
 		name    string
@@ -358,7 +472,7 @@ func TestTodoListPostgres_Update(t *testing.T) {
 		{
 			name: "OK_NoInputFields",
 			mock: func() {
-				mock.ExpectExec("UPDATE todo_lists tl SET FROM users_lists ul WHERE (.+)").
+				mock.ExpectExec("UPDATE todo_lists tl SET updated_at=now\\(\\), updated_by=\\$2, version=tl.version\\+1 FROM users_lists ul WHERE (.+)").
 					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			input: args{
@@ -366,13 +480,372 @@ func TestTodoListPostgres_Update(t *testing.T) {
 				userId: 1,
 			},
 		},
+		{
+			name: "Version Mismatch",
+			mock: func() {
+				mock.ExpectExec("UPDATE todo_lists tl SET (.+) FROM users_lists ul WHERE (.+) AND tl.version=\\$4").
+					WithArgs("new title", 1, 1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+				input: todo.UpdateListInput{
+					Title: stringPointer("new title"),
+				},
+				version: intPointer(2),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.Update(tt.input.userId, tt.input.listId, tt.input.input, tt.input.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoListPostgres_GetPositionAfter(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoListPostgres(db)
+
+	type args struct {
+		userId   int
+		position string
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow("r")
+				mock.ExpectQuery("SELECT COALESCE\\(MIN\\(tl.position\\), ''\\) FROM todo_lists tl INNER JOIN users_lists ul on (.+) WHERE (.+)").
+					WithArgs(1, "i").WillReturnRows(rows)
+			},
+			input: args{
+				userId:   1,
+				position: "i",
+			},
+			want: "r",
+		},
+		{
+			name: "Last List",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow("")
+				mock.ExpectQuery("SELECT COALESCE\\(MIN\\(tl.position\\), ''\\) FROM todo_lists tl INNER JOIN users_lists ul on (.+) WHERE (.+)").
+					WithArgs(1, "r").WillReturnRows(rows)
+			},
+			input: args{
+				userId:   1,
+				position: "r",
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetPositionAfter(tt.input.userId, tt.input.position)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoListPostgres_CreateWithItems(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoListPostgres(db)
+
+	parentId := 1
+
+	type args struct {
+		userId int
+		list   todo.TodoList
+		items  []todo.TodoItem
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("INSERT INTO todo_lists").
+					WithArgs("sprint", "", "r", false, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec("INSERT INTO users_lists").WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("review", "", false, nil, "2021-01-31", nil, "i", 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				mock.ExpectExec("INSERT INTO lists_items").WithArgs(2, 10).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO items_tags").WithArgs(10, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("demo", "", false, nil, nil, 10, "i", 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+				mock.ExpectExec("INSERT INTO lists_items").WithArgs(2, 11).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO items_tags").WithArgs(11, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId: 1,
+				list:   todo.TodoList{Title: "sprint", Position: "r"},
+				items: []todo.TodoItem{
+					{Id: 1, Title: "review", DueDate: stringPointer("2021-01-31"), Position: "i"},
+					{Id: 3, Title: "demo", ParentId: &parentId, Position: "i"},
+				},
+			},
+			want: 2,
+		},
+		{
+			name: "Failed Item Insert",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("INSERT INTO todo_lists").
+					WithArgs("sprint", "", "r", false, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec("INSERT INTO users_lists").WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("review", "", false, nil, nil, nil, "i", 1).WillReturnError(errors.New("insert error"))
+
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId: 1,
+				list:   todo.TodoList{Title: "sprint", Position: "r"},
+				items: []todo.TodoItem{
+					{Id: 1, Title: "review", Position: "i"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.CreateWithItems(tt.input.userId, tt.input.list, tt.input.items)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoListPostgres_CreateImported(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoListPostgres(db)
+
+	completedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		userId int
+		list   todo.TodoList
+		items  []todo.TodoItem
+		tags   map[int][]string
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("INSERT INTO todo_lists").
+					WithArgs("groceries", "", "r", false, true, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec("INSERT INTO users_lists").WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("fruit", "", true, completedAt, false, "2021-01-31", nil, "i", 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				mock.ExpectExec("INSERT INTO lists_items").WithArgs(2, 10).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("WITH tag AS \\(INSERT INTO tags (.+) ON CONFLICT (.+) INSERT INTO items_tags").WithArgs(1, "home", 10).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("apples", "", false, nil, true, nil, 10, "i", 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+				mock.ExpectExec("INSERT INTO lists_items").WithArgs(2, 11).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectCommit()
+			},
+			input: args{
+				userId: 1,
+				list:   todo.TodoList{Title: "groceries", Position: "r", Archived: true},
+				items: []todo.TodoItem{
+					{Id: 1, Title: "fruit", Done: true, CompletedAt: &completedAt, DueDate: stringPointer("2021-01-31"), Position: "i"},
+					{Id: 2, Title: "apples", ParentId: intPointer(1), Archived: true, Position: "i"},
+				},
+				tags: map[int][]string{1: {"home"}},
+			},
+			want: 2,
+		},
+		{
+			name: "Failed Item Insert",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("INSERT INTO todo_lists").
+					WithArgs("groceries", "", "r", false, false, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec("INSERT INTO users_lists").WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("fruit", "", false, nil, false, nil, nil, "i", 1).WillReturnError(errors.New("insert error"))
+
+				mock.ExpectRollback()
+			},
+			input: args{
+				userId: 1,
+				list:   todo.TodoList{Title: "groceries", Position: "r"},
+				items: []todo.TodoItem{
+					{Id: 1, Title: "fruit", Position: "i"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.CreateImported(tt.input.userId, tt.input.list, tt.input.items, tt.input.tags)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoListPostgres_Restore(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTodoListPostgres(db)
+
+	deletedAt := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		listId int
+		userId int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"deleted_at"}).AddRow(deletedAt)
+				mock.ExpectQuery("SELECT tl.deleted_at FROM todo_lists tl INNER JOIN users_lists ul on (.+) WHERE (.+)").
+					WithArgs(1, 1).WillReturnRows(rows)
+
+				mock.ExpectExec("UPDATE todo_lists SET deleted_at = NULL, updated_at = now\\(\\), updated_by = (.+), version = version \\+ 1 WHERE (.+)").
+					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec("UPDATE todo_items ti SET deleted_at = NULL, updated_at = now\\(\\), updated_by = (.+) FROM lists_items li WHERE (.+)").
+					WithArgs(1, deletedAt, 1).WillReturnResult(sqlmock.NewResult(0, 3))
+
+				mock.ExpectCommit()
+			},
+			input: args{
+				listId: 1,
+				userId: 1,
+			},
+		},
+		{
+			name: "Not In Trash",
+			mock: func() {
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"deleted_at"})
+				mock.ExpectQuery("SELECT tl.deleted_at FROM todo_lists tl INNER JOIN users_lists ul on (.+) WHERE (.+)").
+					WithArgs(1, 2).WillReturnRows(rows)
+
+				mock.ExpectRollback()
+			},
+			input: args{
+				listId: 2,
+				userId: 1,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 
-			err := r.Update(tt.input.userId, tt.input.listId, tt.input.input)
+			err := r.Restore(tt.input.userId, tt.input.listId)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
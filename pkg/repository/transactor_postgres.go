@@ -0,0 +1,24 @@
+package repository
+
+type TransactorPostgres struct {
+	db Database
+}
+
+func NewTransactorPostgres(db Database) *TransactorPostgres {
+	return &TransactorPostgres{db: db}
+}
+
+// WithinTransaction commits the transaction if fn succeeds and rolls it back otherwise.
+func (t *TransactorPostgres) WithinTransaction(fn func(repos *Repository) error) error {
+	tx, err := begin(t.db)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(NewRepository(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	"testing"
+)
+
+func TestTransactorPostgres_WithinTransaction(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTransactorPostgres(db)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		fn      func(repos *Repository) error
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE todo_items ti SET archived = (.+)").
+					WithArgs(true, 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			fn: func(repos *Repository) error {
+				return repos.TodoItem.SetArchived(1, 2, true)
+			},
+		},
+		{
+			name: "Rolled Back",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE todo_items ti SET archived = (.+)").
+					WithArgs(true, 1, 2).WillReturnError(errors.New("update error"))
+				mock.ExpectRollback()
+			},
+			fn: func(repos *Repository) error {
+				return repos.TodoItem.SetArchived(1, 2, true)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Nested",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("SAVEPOINT nested_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE todo_items ti SET archived = (.+)").
+					WithArgs(true, 1, 2).WillReturnError(errors.New("update error"))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT nested_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("RELEASE SAVEPOINT nested_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SAVEPOINT nested_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE todo_items ti SET archived = (.+)").
+					WithArgs(true, 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("RELEASE SAVEPOINT nested_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			fn: func(repos *Repository) error {
+				err := repos.WithinTransaction(func(repos *Repository) error {
+					return repos.TodoItem.SetArchived(1, 2, true)
+				})
+				if err == nil {
+					return errors.New("expected error")
+				}
+
+				return repos.WithinTransaction(func(repos *Repository) error {
+					return repos.TodoItem.SetArchived(1, 3, true)
+				})
+			},
+		},
+		{
+			name: "Nested Twice",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("SAVEPOINT nested_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE todo_items ti SET archived = (.+)").
+					WithArgs(true, 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("SAVEPOINT nested_2").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE todo_items ti SET archived = (.+)").
+					WithArgs(true, 1, 3).WillReturnError(errors.New("update error"))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT nested_2").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("RELEASE SAVEPOINT nested_2").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT nested_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("RELEASE SAVEPOINT nested_1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			fn: func(repos *Repository) error {
+				err := repos.WithinTransaction(func(repos *Repository) error {
+					if err := repos.TodoItem.SetArchived(1, 2, true); err != nil {
+						return err
+					}
+
+					return repos.WithinTransaction(func(repos *Repository) error {
+						return repos.TodoItem.SetArchived(1, 3, true)
+					})
+				})
+				if err == nil {
+					return errors.New("expected error")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.WithinTransaction(tt.fn)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/zhashkevych/todo-app"
+	"time"
+)
+
+type TrashPostgres struct {
+	db Database
+}
+
+func NewTrashPostgres(db Database) *TrashPostgres {
+	return &TrashPostgres{db: db}
+}
+
+func (r *TrashPostgres) GetLists(userId int) ([]todo.TodoList, error) {
+	var lists []todo.TodoList
+	query := fmt.Sprintf(`SELECT %s FROM %s tl INNER JOIN %s ul on tl.id = ul.list_id
+								WHERE ul.user_id = $1 AND tl.deleted_at IS NOT NULL ORDER BY tl.deleted_at DESC`,
+		todoListColumns, todoListsTable, usersListsTable)
+	err := r.db.Select(&lists, query, userId)
+
+	return lists, err
+}
+
+// GetItems returns deleted items that can be restored on their own: items deleted
+// together with their list or parent item are restored along with them, and items
+// of a deleted list or parent can't be restored until the list or the parent is.
+func (r *TrashPostgres) GetItems(userId int) ([]todo.TodoItem, error) {
+	var items []todo.TodoItem
+	query := fmt.Sprintf(`SELECT %s FROM %s ti INNER JOIN %s li on li.item_id = ti.id INNER JOIN %s ul on ul.list_id = li.list_id
+									INNER JOIN %s tl on tl.id = li.list_id LEFT JOIN %s p on p.id = ti.parent_id
+									WHERE ul.user_id = $1 AND ti.deleted_at IS NOT NULL AND tl.deleted_at IS NULL
+									AND p.deleted_at IS NULL ORDER BY ti.deleted_at DESC`,
+		todoItemColumns, todoItemsTable, listsItemsTable, usersListsTable, todoListsTable, todoItemsTable)
+	err := r.db.Select(&items, query, userId)
+
+	return items, err
+}
+
+// Purge permanently removes lists and items deleted before the given time.
+func (r *TrashPostgres) Purge(before time.Time) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+
+	deleteItemsQuery := fmt.Sprintf("DELETE FROM %s WHERE deleted_at < $1", todoItemsTable)
+	_, err = tx.Exec(deleteItemsQuery, before)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	deleteListsQuery := fmt.Sprintf("DELETE FROM %s WHERE deleted_at < $1", todoListsTable)
+	_, err = tx.Exec(deleteListsQuery, before)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	"github.com/zhashkevych/todo-app"
+	"testing"
+	"time"
+)
+
+func TestTrashPostgres_GetItems(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTrashPostgres(db)
+
+	deletedAt := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		userId  int
+		want    []todo.TodoItem
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "list_id", "deleted_at"}).
+					AddRow(1, "title1", "description1", true, 2, deletedAt)
+
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti INNER JOIN lists_items li on (.+) WHERE ul.user_id = (.+) AND ti.deleted_at IS NOT NULL AND tl.deleted_at IS NULL AND p.deleted_at IS NULL ORDER BY (.+)").
+					WithArgs(1).WillReturnRows(rows)
+			},
+			userId: 1,
+			want: []todo.TodoItem{
+				{Id: 1, Title: "title1", Description: "description1", Done: true, ListId: 2, DeletedAt: &deletedAt},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetItems(tt.userId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTrashPostgres_Purge(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTrashPostgres(db)
+
+	before := time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("DELETE FROM todo_items WHERE deleted_at < (.+)").
+					WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 5))
+
+				mock.ExpectExec("DELETE FROM todo_lists WHERE deleted_at < (.+)").
+					WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Failed Items Delete",
+			mock: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("DELETE FROM todo_items WHERE deleted_at < (.+)").
+					WithArgs(before).WillReturnError(errors.New("delete error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.Purge(before)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
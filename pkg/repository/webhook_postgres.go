@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/zhashkevych/todo-app"
+	"time"
+)
+
+const (
+	webhookColumns         = "w.id, w.list_id, w.url, w.events, w.created_at"
+	webhookDeliveryColumns = "d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.response_status, d.error, " +
+		"d.next_attempt_at, d.delivered_at, d.created_at"
+	deliveriesLimit = 100
+)
+
+type WebhookPostgres struct {
+	db Database
+}
+
+func NewWebhookPostgres(db Database) *WebhookPostgres {
+	return &WebhookPostgres{db: db}
+}
+
+func (r *WebhookPostgres) Create(userId int, webhook todo.Webhook) (int, error) {
+	var id int
+	query := fmt.Sprintf("INSERT INTO %s (user_id, list_id, url, secret, events) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		webhooksTable)
+	row := r.db.QueryRow(query, userId, webhook.ListId, webhook.Url, webhook.Secret, webhook.Events)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *WebhookPostgres) GetAll(userId int) ([]todo.Webhook, error) {
+	var webhooks []todo.Webhook
+	query := fmt.Sprintf("SELECT %s FROM %s w WHERE w.user_id = $1 ORDER BY w.id", webhookColumns, webhooksTable)
+	err := r.db.Select(&webhooks, query, userId)
+
+	return webhooks, err
+}
+
+func (r *WebhookPostgres) GetById(userId, webhookId int) (todo.Webhook, error) {
+	var webhook todo.Webhook
+	query := fmt.Sprintf("SELECT %s FROM %s w WHERE w.user_id = $1 AND w.id = $2", webhookColumns, webhooksTable)
+	err := r.db.Get(&webhook, query, userId, webhookId)
+
+	return webhook, err
+}
+
+func (r *WebhookPostgres) Delete(userId, webhookId int) error {
+	query := fmt.Sprintf("DELETE FROM %s w WHERE w.user_id = $1 AND w.id = $2", webhooksTable)
+	_, err := r.db.Exec(query, userId, webhookId)
+
+	return err
+}
+
+// GetDeliveries returns the latest deliveries of the webhook, newest first.
+func (r *WebhookPostgres) GetDeliveries(userId, webhookId int) ([]todo.WebhookDelivery, error) {
+	var deliveries []todo.WebhookDelivery
+	query := fmt.Sprintf(`SELECT %s FROM %s d INNER JOIN %s w on w.id = d.webhook_id
+									WHERE w.user_id = $1 AND w.id = $2 ORDER BY d.id DESC LIMIT %d`,
+		webhookDeliveryColumns, webhookDeliveriesTable, webhooksTable, deliveriesLimit)
+	err := r.db.Select(&deliveries, query, userId, webhookId)
+
+	return deliveries, err
+}
+
+// Enqueue schedules deliveries of the event to the webhooks subscribed to it: the webhooks
+// of the list and the webhooks of the users the list is shared with.
+func (r *WebhookPostgres) Enqueue(event string, listId int, payload []byte) error {
+	query := fmt.Sprintf(`INSERT INTO %s (webhook_id, event, payload)
+									SELECT w.id, $1, $2 FROM %s w
+									WHERE (w.list_id = $3 OR w.list_id IS NULL AND w.user_id IN (SELECT user_id FROM %s WHERE list_id = $3))
+									AND (cardinality(w.events) = 0 OR $1 = ANY(w.events))`,
+		webhookDeliveriesTable, webhooksTable, usersListsTable)
+	_, err := r.db.Exec(query, event, payload, listId)
+
+	return err
+}
+
+// Replay schedules a new delivery with the payload of the given one.
+func (r *WebhookPostgres) Replay(userId, webhookId, deliveryId int) (int, error) {
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (webhook_id, event, payload)
+									SELECT d.webhook_id, d.event, d.payload FROM %s d INNER JOIN %s w on w.id = d.webhook_id
+									WHERE w.user_id = $1 AND w.id = $2 AND d.id = $3 RETURNING id`,
+		webhookDeliveriesTable, webhookDeliveriesTable, webhooksTable)
+	row := r.db.QueryRow(query, userId, webhookId, deliveryId)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Claim returns up to limit pending deliveries that are due and postpones their next attempt
+// by lease, so that they aren't claimed again while being sent.
+func (r *WebhookPostgres) Claim(limit int, lease time.Duration) ([]todo.PendingDelivery, error) {
+	var deliveries []todo.PendingDelivery
+	query := fmt.Sprintf(`WITH due AS (
+									SELECT id FROM %s WHERE status = $1 AND next_attempt_at <= now()
+									ORDER BY next_attempt_at LIMIT $2 FOR UPDATE SKIP LOCKED),
+								claimed AS (
+									UPDATE %s d SET next_attempt_at = now() + $3 * interval '1 second' FROM due
+									WHERE d.id = due.id RETURNING d.*)
+								SELECT %s, w.url, w.secret FROM claimed d INNER JOIN %s w on w.id = d.webhook_id ORDER BY d.id`,
+		webhookDeliveriesTable, webhookDeliveriesTable, webhookDeliveryColumns, webhooksTable)
+	err := r.db.Select(&deliveries, query, todo.DeliveryStatusPending, limit, lease.Seconds())
+
+	return deliveries, err
+}
+
+// Complete records a successful attempt of the delivery.
+func (r *WebhookPostgres) Complete(deliveryId, responseStatus int) error {
+	query := fmt.Sprintf(`UPDATE %s SET status = $1, attempts = attempts + 1, response_status = $2, error = NULL,
+									delivered_at = now() WHERE id = $3`,
+		webhookDeliveriesTable)
+	_, err := r.db.Exec(query, todo.DeliveryStatusDelivered, responseStatus, deliveryId)
+
+	return err
+}
+
+// Fail records a failed attempt of the delivery. The delivery is retried at nextAttemptAt
+// or marked as failed if it's nil.
+func (r *WebhookPostgres) Fail(deliveryId int, responseStatus *int, message string, nextAttemptAt *time.Time) error {
+	status := todo.DeliveryStatusFailed
+	if nextAttemptAt != nil {
+		status = todo.DeliveryStatusPending
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET status = $1, attempts = attempts + 1, response_status = $2, error = $3,
+									next_attempt_at = COALESCE($4, next_attempt_at) WHERE id = $5`,
+		webhookDeliveriesTable)
+	_, err := r.db.Exec(query, status, responseStatus, message, nextAttemptAt, deliveryId)
+
+	return err
+}
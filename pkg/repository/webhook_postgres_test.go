@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+	"github.com/zhashkevych/todo-app"
+	"testing"
+	"time"
+)
+
+func TestWebhookPostgres_Create(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewWebhookPostgres(db)
+
+	type args struct {
+		userId  int
+		webhook todo.Webhook
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+				mock.ExpectQuery("INSERT INTO webhooks").
+					WithArgs(1, 2, "https://example.com/hook", "0123456789abcdef", `{"item.created","item.completed"}`).
+					WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				webhook: todo.Webhook{
+					ListId: intPointer(2),
+					Url:    "https://example.com/hook",
+					Secret: "0123456789abcdef",
+					Events: pq.StringArray{todo.EventItemCreated, todo.EventItemCompleted},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "Account Webhook",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(2)
+				mock.ExpectQuery("INSERT INTO webhooks").
+					WithArgs(1, nil, "https://example.com/hook", "0123456789abcdef", "{}").
+					WillReturnRows(rows)
+			},
+			input: args{
+				userId: 1,
+				webhook: todo.Webhook{
+					Url:    "https://example.com/hook",
+					Secret: "0123456789abcdef",
+					Events: pq.StringArray{},
+				},
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.Create(tt.input.userId, tt.input.webhook)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookPostgres_Enqueue(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewWebhookPostgres(db)
+
+	payload := []byte(`{"event":"item.created","list_id":1}`)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectExec("INSERT INTO webhook_deliveries (.+) SELECT (.+) FROM webhooks w WHERE (.+)").
+					WithArgs("item.created", payload, 1).WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "Failed Insert",
+			mock: func() {
+				mock.ExpectExec("INSERT INTO webhook_deliveries (.+) SELECT (.+) FROM webhooks w WHERE (.+)").
+					WithArgs("item.created", payload, 1).WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.Enqueue("item.created", 1, payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookPostgres_Replay(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewWebhookPostgres(db)
+
+	type args struct {
+		userId     int
+		webhookId  int
+		deliveryId int
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(4)
+				mock.ExpectQuery("INSERT INTO webhook_deliveries (.+) SELECT (.+) FROM webhook_deliveries d INNER JOIN webhooks w (.+) WHERE (.+)").
+					WithArgs(1, 2, 3).WillReturnRows(rows)
+			},
+			input: args{
+				userId:     1,
+				webhookId:  2,
+				deliveryId: 3,
+			},
+			want: 4,
+		},
+		{
+			name: "Not Found",
+			mock: func() {
+				mock.ExpectQuery("INSERT INTO webhook_deliveries (.+) SELECT (.+) FROM webhook_deliveries d INNER JOIN webhooks w (.+) WHERE (.+)").
+					WithArgs(1, 2, 3).WillReturnError(sql.ErrNoRows)
+			},
+			input: args{
+				userId:     1,
+				webhookId:  2,
+				deliveryId: 3,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.Replay(tt.input.userId, tt.input.webhookId, tt.input.deliveryId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookPostgres_Fail(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewWebhookPostgres(db)
+
+	nextAttemptAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		deliveryId     int
+		responseStatus *int
+		message        string
+		nextAttemptAt  *time.Time
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		input   args
+		wantErr bool
+	}{
+		{
+			name: "Retry",
+			mock: func() {
+				mock.ExpectExec("UPDATE webhook_deliveries SET status = (.+), attempts = attempts \\+ 1, (.+) WHERE id = (.+)").
+					WithArgs("pending", 500, "unexpected response status 500", nextAttemptAt, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				deliveryId:     1,
+				responseStatus: intPointer(500),
+				message:        "unexpected response status 500",
+				nextAttemptAt:  &nextAttemptAt,
+			},
+		},
+		{
+			name: "Out Of Attempts",
+			mock: func() {
+				mock.ExpectExec("UPDATE webhook_deliveries SET status = (.+), attempts = attempts \\+ 1, (.+) WHERE id = (.+)").
+					WithArgs("failed", nil, "connection refused", nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: args{
+				deliveryId: 1,
+				message:    "connection refused",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.Fail(tt.input.deliveryId, tt.input.responseStatus, tt.input.message, tt.input.nextAttemptAt)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/pb"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationMetadata = "authorization"
+
+	// the methods of the Auth service don't require a token
+	authServicePrefix = "/todo.Auth/"
+)
+
+type userIdKey struct{}
+
+// authInterceptor authenticates calls by the bearer token in the authorization metadata,
+// the same token the REST API accepts in the Authorization header.
+func authInterceptor(auth service.Authorization) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, authServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(authorizationMetadata)
+		if len(values) == 0 || values[0] == "" {
+			return nil, status.Error(codes.Unauthenticated, "empty auth header")
+		}
+
+		headerParts := strings.Split(values[0], " ")
+		if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+			return nil, status.Error(codes.Unauthenticated, "invalid auth header")
+		}
+
+		if len(headerParts[1]) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "token is empty")
+		}
+
+		userId, err := auth.ParseToken(headerParts[1])
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return handler(context.WithValue(ctx, userIdKey{}, userId), req)
+	}
+}
+
+func getUserId(ctx context.Context) (int, error) {
+	id, ok := ctx.Value(userIdKey{}).(int)
+	if !ok {
+		return 0, status.Error(codes.Internal, "user id not found")
+	}
+
+	return id, nil
+}
+
+type authServer struct {
+	pb.UnimplementedAuthServer
+	services *service.Service
+}
+
+func (s *authServer) SignUp(ctx context.Context, req *pb.SignUpRequest) (*pb.IdResponse, error) {
+	if req.Name == "" || req.Username == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid input body")
+	}
+
+	id, err := s.services.Authorization.CreateUser(todo.User{Name: req.Name, Username: req.Username, Password: req.Password})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.IdResponse{Id: int64(id)}, nil
+}
+
+func (s *authServer) SignIn(ctx context.Context, req *pb.SignInRequest) (*pb.SignInResponse, error) {
+	if req.Username == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid input body")
+	}
+
+	token, err := s.services.Authorization.GenerateToken(req.Username, req.Password)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.SignInResponse{Token: token}, nil
+}
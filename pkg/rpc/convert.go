@@ -0,0 +1,204 @@
+package rpc
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// toStatus maps the errors returned by the services to status codes like errorStatus
+// of the REST handlers does to response statuses.
+func toStatus(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, todo.ErrVersionMismatch):
+		code = codes.FailedPrecondition
+	case errors.Is(err, todo.ErrInvalidPatch), errors.Is(err, todo.ErrInvalidOperation):
+		code = codes.InvalidArgument
+	case errors.Is(err, todo.ErrNothingToUndo), errors.Is(err, sql.ErrNoRows):
+		code = codes.NotFound
+	case errors.Is(err, todo.ErrUndoConflict):
+		code = codes.Aborted
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// validate checks the input by the binding tags the REST handlers validate it by.
+func validate(input interface{}) error {
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func toPatch(req *pb.PatchRequest) (todo.Patch, error) {
+	patch := todo.Patch{Document: req.Document}
+	switch req.Type {
+	case pb.PatchType_MERGE_PATCH:
+		patch.Type = todo.MergePatch
+	case pb.PatchType_JSON_PATCH:
+		patch.Type = todo.JSONPatch
+	default:
+		return patch, status.Errorf(codes.InvalidArgument, "unsupported patch type %d", req.Type)
+	}
+
+	return patch, nil
+}
+
+func toNewItem(req *pb.CreateItemRequest) todo.TodoItem {
+	return todo.TodoItem{Title: req.Title, Description: req.Description, DueDate: optionalString(req.DueDate)}
+}
+
+func toUpdateItemInput(req *pb.UpdateItemRequest) todo.UpdateItemInput {
+	return todo.UpdateItemInput{
+		Title:       stringPointer(req.Title),
+		Description: stringPointer(req.Description),
+		Done:        boolPointer(req.Done),
+		DueDate:     stringPointer(req.DueDate),
+	}
+}
+
+func toMoveItemInput(req *pb.MoveItemRequest) todo.MoveItemInput {
+	return todo.MoveItemInput{
+		ListId:   intPointer(req.ListId),
+		BeforeId: intPointer(req.BeforeId),
+		AfterId:  intPointer(req.AfterId),
+	}
+}
+
+// toItemOperation converts an operation of a bulk request. An operation
+// of none of the types is left without one for the validation to reject.
+func toItemOperation(operation *pb.ItemOperation) todo.ItemOperation {
+	switch op := operation.Operation.(type) {
+	case *pb.ItemOperation_Create:
+		item := toNewItem(op.Create)
+		return todo.ItemOperation{Op: todo.OperationCreate, ListId: int(op.Create.ListId), Item: &item}
+	case *pb.ItemOperation_Update:
+		input := toUpdateItemInput(op.Update)
+		return todo.ItemOperation{Op: todo.OperationUpdate, Id: int(op.Update.Id), Update: &input, Version: intPointer(op.Update.Version)}
+	case *pb.ItemOperation_Delete:
+		return todo.ItemOperation{Op: todo.OperationDelete, Id: int(op.Delete.Id), Version: intPointer(op.Delete.Version)}
+	case *pb.ItemOperation_Move:
+		input := toMoveItemInput(op.Move)
+		return todo.ItemOperation{Op: todo.OperationMove, Id: int(op.Move.Id), Move: &input}
+	default:
+		return todo.ItemOperation{}
+	}
+}
+
+func toList(list todo.TodoList) *pb.List {
+	return &pb.List{
+		Id:          int64(list.Id),
+		Title:       list.Title,
+		Description: list.Description,
+		Position:    list.Position,
+		IsTemplate:  list.IsTemplate,
+		Archived:    list.Archived,
+		CreatedAt:   timestamppb.New(list.CreatedAt),
+		UpdatedAt:   timestamppb.New(list.UpdatedAt),
+		CreatedBy:   int64Value(list.CreatedBy),
+		UpdatedBy:   int64Value(list.UpdatedBy),
+		Version:     int64(list.Version),
+	}
+}
+
+func toItem(item todo.TodoItem) *pb.Item {
+	message := &pb.Item{
+		Id:          int64(item.Id),
+		Title:       item.Title,
+		Description: item.Description,
+		Done:        item.Done,
+		ListId:      int64(item.ListId),
+		ParentId:    int64Value(item.ParentId),
+		Position:    item.Position,
+		Archived:    item.Archived,
+		CreatedAt:   timestamppb.New(item.CreatedAt),
+		UpdatedAt:   timestamppb.New(item.UpdatedAt),
+		CreatedBy:   int64Value(item.CreatedBy),
+		UpdatedBy:   int64Value(item.UpdatedBy),
+		CompletedAt: timestamp(item.CompletedAt),
+		Version:     int64(item.Version),
+	}
+
+	if item.DueDate != nil {
+		message.DueDate = *item.DueDate
+	}
+
+	if item.Progress != nil {
+		message.Progress = &pb.ItemProgress{Total: int64(item.Progress.Total), Done: int64(item.Progress.Done)}
+	}
+
+	return message
+}
+
+func toItems(items []todo.TodoItem) *pb.TodoItems {
+	messages := make([]*pb.Item, len(items))
+	for i, item := range items {
+		messages[i] = toItem(item)
+	}
+
+	return &pb.TodoItems{Items: messages}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func int64Value(i *int) *wrapperspb.Int64Value {
+	if i == nil {
+		return nil
+	}
+
+	return wrapperspb.Int64(int64(*i))
+}
+
+func intPointer(value *wrapperspb.Int64Value) *int {
+	if value == nil {
+		return nil
+	}
+
+	i := int(value.Value)
+	return &i
+}
+
+func stringPointer(value *wrapperspb.StringValue) *string {
+	if value == nil {
+		return nil
+	}
+
+	return &value.Value
+}
+
+func boolPointer(value *wrapperspb.BoolValue) *bool {
+	if value == nil {
+		return nil
+	}
+
+	return &value.Value
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
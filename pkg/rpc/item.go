@@ -0,0 +1,271 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/pb"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type itemServer struct {
+	pb.UnimplementedTodoItemServer
+	services *service.Service
+}
+
+func (s *itemServer) Create(ctx context.Context, req *pb.CreateItemRequest) (*pb.IdResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item := toNewItem(req)
+	if err := validate(item); err != nil {
+		return nil, err
+	}
+
+	id, err := s.services.TodoItem.Create(userId, int(req.ListId), item)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.IdResponse{Id: int64(id)}, nil
+}
+
+func (s *itemServer) CreateChild(ctx context.Context, req *pb.CreateChildItemRequest) (*pb.IdResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item := todo.TodoItem{Title: req.Title, Description: req.Description, DueDate: optionalString(req.DueDate)}
+	if err := validate(item); err != nil {
+		return nil, err
+	}
+
+	id, err := s.services.TodoItem.CreateChild(userId, int(req.ParentId), item)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.IdResponse{Id: int64(id)}, nil
+}
+
+func (s *itemServer) GetAll(ctx context.Context, req *pb.GetAllItemsRequest) (*pb.TodoItems, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := todo.ItemFilter{Tag: req.Tag, IncludeArchived: req.IncludeArchived, Sort: req.Sort}
+	if err := validate(filter); err != nil {
+		return nil, err
+	}
+
+	items, _, err := s.services.TodoItem.GetAll(userId, int(req.ListId), filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toItems(items), nil
+}
+
+func (s *itemServer) GetAllByUser(ctx context.Context, req *pb.GetUserItemsRequest) (*pb.TodoItems, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := todo.ItemFilter{Tag: req.Tag, IncludeArchived: req.IncludeArchived, Sort: req.Sort}
+	if err := validate(filter); err != nil {
+		return nil, err
+	}
+
+	items, _, err := s.services.TodoItem.GetAllByUser(userId, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toItems(items), nil
+}
+
+func (s *itemServer) GetChildren(ctx context.Context, req *pb.IdRequest) (*pb.TodoItems, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, _, err := s.services.TodoItem.GetChildren(userId, int(req.Id), todo.ItemFilter{})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toItems(items), nil
+}
+
+func (s *itemServer) GetById(ctx context.Context, req *pb.IdRequest) (*pb.Item, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.services.TodoItem.GetById(userId, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toItem(item), nil
+}
+
+func (s *itemServer) Update(ctx context.Context, req *pb.UpdateItemRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := toUpdateItemInput(req)
+	if err := input.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.services.TodoItem.Update(userId, int(req.Id), input, intPointer(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *itemServer) Patch(ctx context.Context, req *pb.PatchRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := toPatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoItem.Patch(userId, int(req.Id), patch, intPointer(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *itemServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoItem.Delete(userId, int(req.Id), intPointer(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *itemServer) Restore(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoItem.Restore(userId, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *itemServer) Move(ctx context.Context, req *pb.MoveItemRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := toMoveItemInput(req)
+	if err := input.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.services.TodoItem.Move(userId, int(req.Id), input); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *itemServer) Archive(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoItem.Archive(userId, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *itemServer) Unarchive(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoItem.Unarchive(userId, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *itemServer) Copy(ctx context.Context, req *pb.CopyItemRequest) (*pb.IdResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := todo.CopyItemInput{ListId: int(req.ListId)}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	id, err := s.services.TodoItem.Copy(userId, int(req.Id), input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.IdResponse{Id: int64(id)}, nil
+}
+
+func (s *itemServer) Bulk(ctx context.Context, req *pb.BulkItemsRequest) (*pb.BulkItemsResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := todo.BulkItemsInput{Atomic: req.Atomic, Operations: make([]todo.ItemOperation, len(req.Operations))}
+	for i, operation := range req.Operations {
+		input.Operations[i] = toItemOperation(operation)
+	}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	results, err := s.services.TodoItem.Bulk(userId, input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	messages := make([]*pb.ItemOperationResult, len(results))
+	for i, result := range results {
+		messages[i] = &pb.ItemOperationResult{Status: result.Status, Id: int64(result.Id), Error: result.Error}
+	}
+
+	return &pb.BulkItemsResponse{Results: messages}, nil
+}
@@ -0,0 +1,211 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/pb"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type listServer struct {
+	pb.UnimplementedTodoListServer
+	services *service.Service
+}
+
+func (s *listServer) Create(ctx context.Context, req *pb.CreateListRequest) (*pb.IdResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := todo.TodoList{Title: req.Title, Description: req.Description, IsTemplate: req.IsTemplate}
+	if err := validate(list); err != nil {
+		return nil, err
+	}
+
+	id, err := s.services.TodoList.Create(userId, list)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.IdResponse{Id: int64(id)}, nil
+}
+
+func (s *listServer) GetAll(ctx context.Context, req *pb.GetAllListsRequest) (*pb.TodoLists, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := todo.ListFilter{IncludeArchived: req.IncludeArchived, Sort: req.Sort}
+	if err := validate(filter); err != nil {
+		return nil, err
+	}
+
+	lists, _, err := s.services.TodoList.GetAll(userId, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	messages := make([]*pb.List, len(lists))
+	for i, list := range lists {
+		messages[i] = toList(list)
+	}
+
+	return &pb.TodoLists{Lists: messages}, nil
+}
+
+func (s *listServer) GetById(ctx context.Context, req *pb.IdRequest) (*pb.List, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.services.TodoList.GetById(userId, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toList(list), nil
+}
+
+func (s *listServer) Update(ctx context.Context, req *pb.UpdateListRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := todo.UpdateListInput{
+		Title:       stringPointer(req.Title),
+		Description: stringPointer(req.Description),
+		IsTemplate:  boolPointer(req.IsTemplate),
+	}
+	if err := input.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.services.TodoList.Update(userId, int(req.Id), input, intPointer(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *listServer) Patch(ctx context.Context, req *pb.PatchRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := toPatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoList.Patch(userId, int(req.Id), patch, intPointer(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *listServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoList.Delete(userId, int(req.Id), intPointer(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *listServer) Restore(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoList.Restore(userId, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *listServer) Move(ctx context.Context, req *pb.MoveListRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := todo.MoveListInput{BeforeId: intPointer(req.BeforeId), AfterId: intPointer(req.AfterId)}
+	if err := input.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.services.TodoList.Move(userId, int(req.Id), input); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *listServer) Archive(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoList.Archive(userId, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *listServer) Unarchive(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.TodoList.Unarchive(userId, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *listServer) Duplicate(ctx context.Context, req *pb.DuplicateListRequest) (*pb.IdResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := todo.DuplicateListInput{Title: stringPointer(req.Title), ResetDone: req.ResetDone}
+	id, err := s.services.TodoList.Duplicate(userId, int(req.Id), input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.IdResponse{Id: int64(id)}, nil
+}
+
+func (s *listServer) Instantiate(ctx context.Context, req *pb.InstantiateListRequest) (*pb.IdResponse, error) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.services.TodoList.Instantiate(userId, int(req.Id), todo.InstantiateListInput{Values: req.Values})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.IdResponse{Id: int64(id)}, nil
+}
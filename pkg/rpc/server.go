@@ -0,0 +1,56 @@
+// Package rpc serves the lists and items over gRPC for other backend services,
+// calling the same services as the REST API.
+package rpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/zhashkevych/todo-app/pkg/pb"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	grpcServer *grpc.Server
+}
+
+func NewServer(services *service.Service) *Server {
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(authInterceptor(services.Authorization)))
+
+	pb.RegisterAuthServer(grpcServer, &authServer{services: services})
+	pb.RegisterTodoListServer(grpcServer, &listServer{services: services})
+	pb.RegisterTodoItemServer(grpcServer, &itemServer{services: services})
+
+	return &Server{grpcServer: grpcServer}
+}
+
+func (s *Server) Run(port string) error {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(listener)
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	return s.grpcServer.Serve(listener)
+}
+
+// Shutdown waits for the pending calls to finish, or cancels them when ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
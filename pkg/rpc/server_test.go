@@ -0,0 +1,371 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/pb"
+	"github.com/zhashkevych/todo-app/pkg/service"
+	service_mocks "github.com/zhashkevych/todo-app/pkg/service/mocks"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"net"
+	"testing"
+	"time"
+)
+
+// dial serves the services on an in-memory listener and connects to it.
+func dial(t *testing.T, services *service.Service) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(services)
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Shutdown(context.Background()) })
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when dialing the server", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), authorizationMetadata, token)
+}
+
+func TestServer_authInterceptor(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockAuthorization, lists *service_mocks.MockTodoList)
+
+	createdAt := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		token         string
+		mockBehavior  mockBehavior
+		expectedCode  codes.Code
+		expectedError string
+	}{
+		{
+			name:  "Ok",
+			token: "Bearer token",
+			mockBehavior: func(r *service_mocks.MockAuthorization, lists *service_mocks.MockTodoList) {
+				r.EXPECT().ParseToken("token").Return(1, nil)
+				lists.EXPECT().GetById(1, 2).Return(todo.TodoList{Id: 2, Title: "Groceries", CreatedAt: createdAt}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:          "No Token",
+			mockBehavior:  func(r *service_mocks.MockAuthorization, lists *service_mocks.MockTodoList) {},
+			expectedCode:  codes.Unauthenticated,
+			expectedError: "empty auth header",
+		},
+		{
+			name:          "Invalid Header",
+			token:         "Basic token",
+			mockBehavior:  func(r *service_mocks.MockAuthorization, lists *service_mocks.MockTodoList) {},
+			expectedCode:  codes.Unauthenticated,
+			expectedError: "invalid auth header",
+		},
+		{
+			name:  "Invalid Token",
+			token: "Bearer token",
+			mockBehavior: func(r *service_mocks.MockAuthorization, lists *service_mocks.MockTodoList) {
+				r.EXPECT().ParseToken("token").Return(0, errors.New("invalid token"))
+			},
+			expectedCode:  codes.Unauthenticated,
+			expectedError: "invalid token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			lists := service_mocks.NewMockTodoList(c)
+			test.mockBehavior(auth, lists)
+
+			conn := dial(t, &service.Service{Authorization: auth, TodoList: lists})
+
+			ctx := context.Background()
+			if test.token != "" {
+				ctx = withToken(test.token)
+			}
+
+			// Make Call
+			list, err := pb.NewTodoListClient(conn).GetById(ctx, &pb.IdRequest{Id: 2})
+
+			// Assert
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			if test.expectedCode == codes.OK {
+				assert.Equal(t, int64(2), list.Id)
+				assert.Equal(t, "Groceries", list.Title)
+				assert.Equal(t, createdAt, list.CreatedAt.AsTime())
+			} else {
+				assert.Equal(t, test.expectedError, status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestServer_signIn(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	auth := service_mocks.NewMockAuthorization(c)
+	auth.EXPECT().GenerateToken("test", "password").Return("token", nil)
+
+	conn := dial(t, &service.Service{Authorization: auth})
+
+	// the auth service is called without a token
+	resp, err := pb.NewAuthClient(conn).SignIn(context.Background(), &pb.SignInRequest{Username: "test", Password: "password"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token", resp.Token)
+}
+
+func TestServer_updateItem(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem)
+
+	done := true
+	version := 3
+
+	tests := []struct {
+		name          string
+		input         *pb.UpdateItemRequest
+		mockBehavior  mockBehavior
+		expectedCode  codes.Code
+		expectedError string
+	}{
+		{
+			name:  "Ok",
+			input: &pb.UpdateItemRequest{Id: 1, Done: wrapperspb.Bool(true), Version: wrapperspb.Int64(3)},
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Update(1, 1, todo.UpdateItemInput{Done: &done}, &version).Return(nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:  "Version Mismatch",
+			input: &pb.UpdateItemRequest{Id: 1, Done: wrapperspb.Bool(true), Version: wrapperspb.Int64(3)},
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Update(1, 1, todo.UpdateItemInput{Done: &done}, &version).Return(todo.ErrVersionMismatch)
+			},
+			expectedCode:  codes.FailedPrecondition,
+			expectedError: todo.ErrVersionMismatch.Error(),
+		},
+		{
+			name:          "No Values",
+			input:         &pb.UpdateItemRequest{Id: 1},
+			mockBehavior:  func(r *service_mocks.MockTodoItem) {},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: "update structure has no values",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil)
+
+			items := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(items)
+
+			conn := dial(t, &service.Service{Authorization: auth, TodoItem: items})
+
+			// Make Call
+			_, err := pb.NewTodoItemClient(conn).Update(withToken("Bearer token"), test.input)
+
+			// Assert
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			if test.expectedCode != codes.OK {
+				assert.Equal(t, test.expectedError, status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestServer_patchItem(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem)
+
+	version := 3
+
+	tests := []struct {
+		name          string
+		input         *pb.PatchRequest
+		mockBehavior  mockBehavior
+		expectedCode  codes.Code
+		expectedError string
+	}{
+		{
+			name:  "Merge Patch",
+			input: &pb.PatchRequest{Id: 1, Document: []byte(`{"done":true}`), Version: wrapperspb.Int64(3)},
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Patch(1, 1, todo.Patch{Type: todo.MergePatch, Document: []byte(`{"done":true}`)}, &version).Return(nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:  "JSON Patch",
+			input: &pb.PatchRequest{Id: 1, Type: pb.PatchType_JSON_PATCH, Document: []byte(`[{"op":"remove","path":"/due_date"}]`)},
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Patch(1, 1, todo.Patch{Type: todo.JSONPatch, Document: []byte(`[{"op":"remove","path":"/due_date"}]`)}, nil).Return(nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:  "Invalid Patch",
+			input: &pb.PatchRequest{Id: 1, Document: []byte(`{"done":"yes"}`)},
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Patch(1, 1, todo.Patch{Type: todo.MergePatch, Document: []byte(`{"done":"yes"}`)}, nil).Return(todo.ErrInvalidPatch)
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: todo.ErrInvalidPatch.Error(),
+		},
+		{
+			name:          "Unsupported Type",
+			input:         &pb.PatchRequest{Id: 1, Type: 5, Document: []byte(`{"done":true}`)},
+			mockBehavior:  func(r *service_mocks.MockTodoItem) {},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: "unsupported patch type 5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil)
+
+			items := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(items)
+
+			conn := dial(t, &service.Service{Authorization: auth, TodoItem: items})
+
+			// Make Call
+			_, err := pb.NewTodoItemClient(conn).Patch(withToken("Bearer token"), test.input)
+
+			// Assert
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			if test.expectedCode != codes.OK {
+				assert.Equal(t, test.expectedError, status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestServer_bulkItems(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *service_mocks.MockTodoItem)
+
+	done := true
+	version := 3
+	afterId := 4
+
+	tests := []struct {
+		name            string
+		input           *pb.BulkItemsRequest
+		mockBehavior    mockBehavior
+		expectedResults []*pb.ItemOperationResult
+		expectedCode    codes.Code
+		expectedError   string
+	}{
+		{
+			name: "Ok",
+			input: &pb.BulkItemsRequest{Atomic: true, Operations: []*pb.ItemOperation{
+				{Operation: &pb.ItemOperation_Create{Create: &pb.CreateItemRequest{ListId: 1, Title: "Bread"}}},
+				{Operation: &pb.ItemOperation_Update{Update: &pb.UpdateItemRequest{Id: 2, Done: wrapperspb.Bool(true), Version: wrapperspb.Int64(3)}}},
+				{Operation: &pb.ItemOperation_Delete{Delete: &pb.DeleteRequest{Id: 3}}},
+				{Operation: &pb.ItemOperation_Move{Move: &pb.MoveItemRequest{Id: 5, AfterId: wrapperspb.Int64(4)}}},
+			}},
+			mockBehavior: func(r *service_mocks.MockTodoItem) {
+				r.EXPECT().Bulk(1, todo.BulkItemsInput{Atomic: true, Operations: []todo.ItemOperation{
+					{Op: todo.OperationCreate, ListId: 1, Item: &todo.TodoItem{Title: "Bread"}},
+					{Op: todo.OperationUpdate, Id: 2, Update: &todo.UpdateItemInput{Done: &done}, Version: &version},
+					{Op: todo.OperationDelete, Id: 3},
+					{Op: todo.OperationMove, Id: 5, Move: &todo.MoveItemInput{AfterId: &afterId}},
+				}}).Return([]todo.ItemOperationResult{
+					{Status: todo.OperationStatusOk, Id: 6},
+					{Status: todo.OperationStatusOk, Id: 2},
+					{Status: todo.OperationStatusOk, Id: 3},
+					{Status: todo.OperationStatusFailed, Error: "sql: no rows in result set"},
+				}, nil)
+			},
+			expectedResults: []*pb.ItemOperationResult{
+				{Status: "ok", Id: 6},
+				{Status: "ok", Id: 2},
+				{Status: "ok", Id: 3},
+				{Status: "failed", Error: "sql: no rows in result set"},
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:          "No Operations",
+			input:         &pb.BulkItemsRequest{},
+			mockBehavior:  func(r *service_mocks.MockTodoItem) {},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: "Key: 'BulkItemsInput.Operations' Error:Field validation for 'Operations' failed on the 'min' tag",
+		},
+		{
+			name:          "Operation Without Type",
+			input:         &pb.BulkItemsRequest{Operations: []*pb.ItemOperation{{}}},
+			mockBehavior:  func(r *service_mocks.MockTodoItem) {},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: "Key: 'BulkItemsInput.Operations[0].Op' Error:Field validation for 'Op' failed on the 'required' tag",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := service_mocks.NewMockAuthorization(c)
+			auth.EXPECT().ParseToken("token").Return(1, nil)
+
+			items := service_mocks.NewMockTodoItem(c)
+			test.mockBehavior(items)
+
+			conn := dial(t, &service.Service{Authorization: auth, TodoItem: items})
+
+			// Make Call
+			resp, err := pb.NewTodoItemClient(conn).Bulk(withToken("Bearer token"), test.input)
+
+			// Assert
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			if test.expectedCode == codes.OK {
+				assert.Equal(t, len(test.expectedResults), len(resp.Results))
+				for i, result := range test.expectedResults {
+					assert.Equal(t, result.Status, resp.Results[i].Status)
+					assert.Equal(t, result.Id, resp.Results[i].Id)
+					assert.Equal(t, result.Error, resp.Results[i].Error)
+				}
+			} else {
+				assert.Equal(t, test.expectedError, status.Convert(err).Message())
+			}
+		})
+	}
+}
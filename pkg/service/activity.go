@@ -0,0 +1,140 @@
+package service
+
+import (
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+const defaultActivityLimit = 50
+
+type ActivityService struct {
+	repo repository.Activity
+}
+
+func NewActivityService(repo repository.Activity) *ActivityService {
+	return &ActivityService{repo: repo}
+}
+
+func (s *ActivityService) GetAll(userId int, filter todo.ActivityFilter) ([]todo.Activity, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultActivityLimit
+	}
+
+	return s.repo.GetAll(userId, filter)
+}
+
+func (s *ActivityService) GetByList(userId, listId int, filter todo.ActivityFilter) ([]todo.Activity, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultActivityLimit
+	}
+
+	return s.repo.GetByList(userId, listId, filter)
+}
+
+// record runs change within a transaction and appends the activity it returns to the log
+// in the same transaction, so a change is never made without being logged.
+func record(transactor repository.Transactor, userId int, change func(repos *repository.Repository) (todo.Activity, error)) error {
+	return transactor.WithinTransaction(func(repos *repository.Repository) error {
+		activity, err := change(repos)
+		if err != nil {
+			return err
+		}
+
+		activity.ActorId = &userId
+		return logActivity(repos, activity)
+	})
+}
+
+// logActivity appends the activity to the log along with the webhook deliveries
+// and the outbox events it causes.
+func logActivity(repos *repository.Repository, activity todo.Activity) error {
+	if err := repos.Activity.Create(activity); err != nil {
+		return err
+	}
+
+	if err := enqueueWebhooks(repos, activity); err != nil {
+		return err
+	}
+
+	return addToOutbox(repos, activity)
+}
+
+// addChange adds the field to changes if its value differs.
+func addChange(changes todo.Changes, field string, from, to interface{}) {
+	if from != to {
+		changes[field] = todo.FieldChange{From: from, To: to}
+	}
+}
+
+// intValue dereferences p so that the values can be compared.
+func intValue(p *int) interface{} {
+	if p == nil {
+		return nil
+	}
+
+	return *p
+}
+
+// stringValue dereferences p, with nil standing for an empty string as in update inputs.
+func stringValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+
+	return *p
+}
+
+func listFields(list todo.TodoList) todo.Changes {
+	changes := make(todo.Changes)
+	addChange(changes, "title", nil, list.Title)
+	addChange(changes, "description", nil, list.Description)
+	addChange(changes, "is_template", nil, list.IsTemplate)
+
+	return changes
+}
+
+func listChanges(list todo.TodoList, input todo.UpdateListInput) todo.Changes {
+	changes := make(todo.Changes)
+	if input.Title != nil {
+		addChange(changes, "title", list.Title, *input.Title)
+	}
+	if input.Description != nil {
+		addChange(changes, "description", list.Description, *input.Description)
+	}
+	if input.IsTemplate != nil {
+		addChange(changes, "is_template", list.IsTemplate, *input.IsTemplate)
+	}
+
+	return changes
+}
+
+func itemFields(item todo.TodoItem) todo.Changes {
+	changes := make(todo.Changes)
+	addChange(changes, "title", nil, item.Title)
+	addChange(changes, "description", nil, item.Description)
+	addChange(changes, "done", nil, item.Done)
+	addChange(changes, "parent_id", nil, intValue(item.ParentId))
+	if item.DueDate != nil {
+		addChange(changes, "due_date", nil, *item.DueDate)
+	}
+
+	return changes
+}
+
+func itemChanges(item todo.TodoItem, input todo.UpdateItemInput) todo.Changes {
+	changes := make(todo.Changes)
+	if input.Title != nil {
+		addChange(changes, "title", item.Title, *input.Title)
+	}
+	if input.Description != nil {
+		addChange(changes, "description", item.Description, *input.Description)
+	}
+	if input.Done != nil {
+		addChange(changes, "done", item.Done, *input.Done)
+	}
+	if input.DueDate != nil {
+		addChange(changes, "due_date", stringValue(item.DueDate), *input.DueDate)
+	}
+
+	return changes
+}
@@ -71,6 +71,10 @@ func (s *AuthService) ParseToken(accessToken string) (int, error) {
 	return claims.UserId, nil
 }
 
+func (s *AuthService) GetUserById(userId int) (todo.User, error) {
+	return s.repo.GetUserById(userId)
+}
+
 func generatePasswordHash(password string) string {
 	hash := sha1.New()
 	hash.Write([]byte(password))
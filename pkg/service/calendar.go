@@ -0,0 +1,85 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+const calendarTokenSize = 24
+
+type CalendarService struct {
+	repo     repository.Calendar
+	listRepo repository.TodoList
+	itemRepo repository.TodoItem
+}
+
+func NewCalendarService(repo repository.Calendar, listRepo repository.TodoList, itemRepo repository.TodoItem) *CalendarService {
+	return &CalendarService{repo: repo, listRepo: listRepo, itemRepo: itemRepo}
+}
+
+// CreateToken generates a new calendar token of the user, replacing the previous one.
+// Only a hash of the token is stored, so it can't be shown again.
+func (s *CalendarService) CreateToken(userId int) (string, error) {
+	b := make([]byte, calendarTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	token := hex.EncodeToString(b)
+	hash := hashCalendarToken(token)
+	if err := s.repo.SetToken(userId, &hash); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *CalendarService) DeleteToken(userId int) error {
+	return s.repo.SetToken(userId, nil)
+}
+
+func (s *CalendarService) GetUserId(token string) (int, error) {
+	return s.repo.GetUserId(hashCalendarToken(token))
+}
+
+func (s *CalendarService) GetDueItems(userId int) ([]todo.TodoItem, error) {
+	return s.repo.GetDueItems(userId)
+}
+
+func (s *CalendarService) GetLists(userId int) ([]todo.TodoList, error) {
+	lists, _, err := s.listRepo.GetAll(userId, todo.ListFilter{})
+
+	return lists, err
+}
+
+// GetList returns the list with its items that aren't archived.
+func (s *CalendarService) GetList(userId, listId int) (todo.ListExport, error) {
+	list, err := s.listRepo.GetById(userId, listId)
+	if err != nil {
+		// list does not exists or does not belongs to user
+		return todo.ListExport{}, err
+	}
+
+	items, err := s.itemRepo.GetAllInList(listId)
+	if err != nil {
+		return todo.ListExport{}, err
+	}
+
+	active := make([]todo.TodoItem, 0, len(items))
+	for _, item := range items {
+		if !item.Archived {
+			active = append(active, item)
+		}
+	}
+
+	return todo.ListExport{TodoList: list, Items: active}, nil
+}
+
+func hashCalendarToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
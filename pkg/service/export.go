@@ -0,0 +1,62 @@
+package service
+
+import (
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+type ExportService struct {
+	listRepo repository.TodoList
+	itemRepo repository.TodoItem
+	tagRepo  repository.Tag
+}
+
+func NewExportService(listRepo repository.TodoList, itemRepo repository.TodoItem, tagRepo repository.Tag) *ExportService {
+	return &ExportService{listRepo: listRepo, itemRepo: itemRepo, tagRepo: tagRepo}
+}
+
+func (s *ExportService) GetList(userId, listId int) (todo.ListExport, error) {
+	list, err := s.listRepo.GetById(userId, listId)
+	if err != nil {
+		// list does not exists or does not belongs to user
+		return todo.ListExport{}, err
+	}
+
+	return s.withItems(userId, list)
+}
+
+// ForEachList passes every list of the user, archived lists and templates included, to fn
+// one at a time, so that an export of the whole account never has to be held in memory.
+func (s *ExportService) ForEachList(userId int, fn func(list todo.ListExport) error) error {
+	lists, _, err := s.listRepo.GetAll(userId, todo.ListFilter{IncludeArchived: true})
+	if err != nil {
+		return err
+	}
+
+	for _, list := range lists {
+		export, err := s.withItems(userId, list)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(export); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ExportService) withItems(userId int, list todo.TodoList) (todo.ListExport, error) {
+	items, err := s.itemRepo.GetAllInList(list.Id)
+	if err != nil {
+		return todo.ListExport{}, err
+	}
+
+	tags, err := s.tagRepo.GetNamesByList(userId, list.Id)
+	if err != nil {
+		return todo.ListExport{}, err
+	}
+
+	return todo.ListExport{TodoList: list, Items: items, Tags: tags}, nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"time"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+type IdempotencyService struct {
+	repo repository.Idempotency
+	ttl  time.Duration
+}
+
+func NewIdempotencyService(repo repository.Idempotency, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{repo: repo, ttl: ttl}
+}
+
+// Begin reserves the key for a request. If the key has already been used for an identical
+// request that has completed, the stored response is returned instead.
+func (s *IdempotencyService) Begin(userId int, key, requestHash string) (*todo.IdempotencyKey, error) {
+	reserved, err := s.repo.Reserve(userId, key, requestHash, time.Now().Add(-s.ttl))
+	if err != nil || reserved {
+		return nil, err
+	}
+
+	stored, err := s.repo.Get(userId, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.RequestHash != requestHash {
+		return nil, todo.ErrIdempotencyKeyReused
+	}
+
+	if stored.StatusCode == nil {
+		return nil, todo.ErrRequestInProgress
+	}
+
+	return &stored, nil
+}
+
+func (s *IdempotencyService) Complete(userId int, key string, statusCode int, headers todo.ResponseHeaders, body []byte) error {
+	return s.repo.Complete(userId, key, statusCode, headers, body)
+}
+
+func (s *IdempotencyService) Release(userId int, key string) error {
+	return s.repo.Release(userId, key)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/rank"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+type ImportService struct {
+	transactor repository.Transactor
+}
+
+func NewImportService(transactor repository.Transactor) *ImportService {
+	return &ImportService{transactor: transactor}
+}
+
+// Import creates the lists after the user's lists within a single transaction, so either all
+// of them are created or none. The creation of the lists and their items is logged like any other,
+// so it can be undone. A dry run creates the lists and rolls them back, which checks
+// that they can be created, and returns them without ids.
+func (s *ImportService) Import(userId int, lists []todo.ListExport, dryRun bool) ([]todo.ImportedList, error) {
+	imported := make([]todo.ImportedList, 0, len(lists))
+
+	err := s.transactor.WithinTransaction(func(repos *repository.Repository) error {
+		last, err := repos.TodoList.GetLastPosition(userId)
+		if err != nil {
+			return err
+		}
+
+		for _, list := range lists {
+			list.Position = rank.After(last)
+			last = list.Position
+
+			id, err := repos.TodoList.CreateImported(userId, list.TodoList, withPositions(list.Items), list.Tags)
+			if err != nil {
+				return err
+			}
+
+			err = logActivity(repos, todo.Activity{
+				ActorId: &userId,
+				ListId:  id,
+				Action:  todo.ActivityCreate,
+				Changes: listFields(list.TodoList),
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := logImportedItems(repos, userId, id); err != nil {
+				return err
+			}
+
+			if dryRun {
+				id = 0
+			}
+			imported = append(imported, todo.ImportedList{Id: id, Title: list.Title, Items: len(list.Items)})
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return imported, nil
+}
+
+// logImportedItems logs the creation of the items imported into the list in the order they were
+// created, parents first, so that undoing the import deletes subtasks before their parents.
+func logImportedItems(repos *repository.Repository, userId, listId int) error {
+	items, err := repos.TodoItem.GetAllInList(listId)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Id < items[j].Id
+	})
+
+	for _, item := range items {
+		id := item.Id
+		err := logActivity(repos, todo.Activity{
+			ActorId: &userId,
+			ListId:  listId,
+			ItemId:  &id,
+			Action:  todo.ActivityCreate,
+			Changes: itemFields(item),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withPositions places the items among their siblings in the order they are given.
+func withPositions(items []todo.TodoItem) []todo.TodoItem {
+	last := make(map[int]string)
+	positioned := make([]todo.TodoItem, len(items))
+
+	for i, item := range items {
+		parent := 0
+		if item.ParentId != nil {
+			parent = *item.ParentId
+		}
+
+		item.Position = rank.After(last[parent])
+		last[parent] = item.Position
+		positioned[i] = item
+	}
+
+	return positioned
+}
@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	todo "github.com/zhashkevych/todo-app"
 	reflect "reflect"
+	time "time"
 )
 
 // MockAuthorization is a mock of Authorization interface
@@ -78,6 +79,21 @@ func (mr *MockAuthorizationMockRecorder) ParseToken(token interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthorization)(nil).ParseToken), token)
 }
 
+// GetUserById mocks base method
+func (m *MockAuthorization) GetUserById(userId int) (todo.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", userId)
+	ret0, _ := ret[0].(todo.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById
+func (mr *MockAuthorizationMockRecorder) GetUserById(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockAuthorization)(nil).GetUserById), userId)
+}
+
 // MockTodoList is a mock of TodoList interface
 type MockTodoList struct {
 	ctrl     *gomock.Controller
@@ -117,18 +133,19 @@ func (mr *MockTodoListMockRecorder) Create(userId, list interface{}) *gomock.Cal
 }
 
 // GetAll mocks base method
-func (m *MockTodoList) GetAll(userId int) ([]todo.TodoList, error) {
+func (m *MockTodoList) GetAll(userId int, filter todo.ListFilter) ([]todo.TodoList, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret := m.ctrl.Call(m, "GetAll", userId, filter)
 	ret0, _ := ret[0].([]todo.TodoList)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll
-func (mr *MockTodoListMockRecorder) GetAll(userId interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) GetAll(userId, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId, filter)
 }
 
 // GetById mocks base method
@@ -147,31 +164,131 @@ func (mr *MockTodoListMockRecorder) GetById(userId, listId interface{}) *gomock.
 }
 
 // Delete mocks base method
-func (m *MockTodoList) Delete(userId, listId int) error {
+func (m *MockTodoList) Delete(userId, listId int, version *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, listId)
+	ret := m.ctrl.Call(m, "Delete", userId, listId, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete
-func (mr *MockTodoListMockRecorder) Delete(userId, listId interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) Delete(userId, listId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), userId, listId, version)
+}
+
+// Restore mocks base method
+func (m *MockTodoList) Restore(userId, listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", userId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore
+func (mr *MockTodoListMockRecorder) Restore(userId, listId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoList)(nil).Restore), userId, listId)
 }
 
 // Update mocks base method
-func (m *MockTodoList) Update(userId, listId int, input todo.UpdateListInput) error {
+func (m *MockTodoList) Update(userId, listId int, input todo.UpdateListInput, version *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, listId, input)
+	ret := m.ctrl.Call(m, "Update", userId, listId, input, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update
-func (mr *MockTodoListMockRecorder) Update(userId, listId, input interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) Update(userId, listId, input, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoList)(nil).Update), userId, listId, input, version)
+}
+
+// Patch mocks base method
+func (m *MockTodoList) Patch(userId, listId int, patch todo.Patch, version *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", userId, listId, patch, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockTodoListMockRecorder) Patch(userId, listId, patch, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockTodoList)(nil).Patch), userId, listId, patch, version)
+}
+
+// Move mocks base method
+func (m *MockTodoList) Move(userId, listId int, input todo.MoveListInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userId, listId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move
+func (mr *MockTodoListMockRecorder) Move(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoList)(nil).Move), userId, listId, input)
+}
+
+// Archive mocks base method
+func (m *MockTodoList) Archive(userId, listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", userId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive
+func (mr *MockTodoListMockRecorder) Archive(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockTodoList)(nil).Archive), userId, listId)
+}
+
+// Unarchive mocks base method
+func (m *MockTodoList) Unarchive(userId, listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", userId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unarchive indicates an expected call of Unarchive
+func (mr *MockTodoListMockRecorder) Unarchive(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockTodoList)(nil).Unarchive), userId, listId)
+}
+
+// Duplicate mocks base method
+func (m *MockTodoList) Duplicate(userId, listId int, input todo.DuplicateListInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Duplicate", userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Duplicate indicates an expected call of Duplicate
+func (mr *MockTodoListMockRecorder) Duplicate(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duplicate", reflect.TypeOf((*MockTodoList)(nil).Duplicate), userId, listId, input)
+}
+
+// Instantiate mocks base method
+func (m *MockTodoList) Instantiate(userId, listId int, input todo.InstantiateListInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instantiate", userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instantiate indicates an expected call of Instantiate
+func (mr *MockTodoListMockRecorder) Instantiate(userId, listId, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoList)(nil).Update), userId, listId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instantiate", reflect.TypeOf((*MockTodoList)(nil).Instantiate), userId, listId, input)
 }
 
 // MockTodoItem is a mock of TodoItem interface
@@ -212,19 +329,82 @@ func (mr *MockTodoItemMockRecorder) Create(userId, listId, item interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoItem)(nil).Create), userId, listId, item)
 }
 
+// CreateChild mocks base method
+func (m *MockTodoItem) CreateChild(userId, parentId int, item todo.TodoItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChild", userId, parentId, item)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChild indicates an expected call of CreateChild
+func (mr *MockTodoItemMockRecorder) CreateChild(userId, parentId, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChild", reflect.TypeOf((*MockTodoItem)(nil).CreateChild), userId, parentId, item)
+}
+
 // GetAll mocks base method
-func (m *MockTodoItem) GetAll(userId, listId int) ([]todo.TodoItem, error) {
+func (m *MockTodoItem) GetAll(userId, listId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, listId, filter)
+	ret0, _ := ret[0].([]todo.TodoItem)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAll indicates an expected call of GetAll
+func (mr *MockTodoItemMockRecorder) GetAll(userId, listId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), userId, listId, filter)
+}
+
+// GetAllByUser mocks base method
+func (m *MockTodoItem) GetAllByUser(userId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUser", userId, filter)
+	ret0, _ := ret[0].([]todo.TodoItem)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllByUser indicates an expected call of GetAllByUser
+func (mr *MockTodoItemMockRecorder) GetAllByUser(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUser", reflect.TypeOf((*MockTodoItem)(nil).GetAllByUser), userId, filter)
+}
+
+// GetAllByLists mocks base method
+func (m *MockTodoItem) GetAllByLists(userId int, listIds []int) ([]todo.TodoItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", userId, listId)
+	ret := m.ctrl.Call(m, "GetAllByLists", userId, listIds)
 	ret0, _ := ret[0].([]todo.TodoItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAll indicates an expected call of GetAll
-func (mr *MockTodoItemMockRecorder) GetAll(userId, listId interface{}) *gomock.Call {
+// GetAllByLists indicates an expected call of GetAllByLists
+func (mr *MockTodoItemMockRecorder) GetAllByLists(userId, listIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByLists", reflect.TypeOf((*MockTodoItem)(nil).GetAllByLists), userId, listIds)
+}
+
+// GetChildren mocks base method
+func (m *MockTodoItem) GetChildren(userId, itemId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", userId, itemId, filter)
+	ret0, _ := ret[0].([]todo.TodoItem)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChildren indicates an expected call of GetChildren
+func (mr *MockTodoItemMockRecorder) GetChildren(userId, itemId, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockTodoItem)(nil).GetChildren), userId, itemId, filter)
 }
 
 // GetById mocks base method
@@ -243,29 +423,936 @@ func (mr *MockTodoItemMockRecorder) GetById(userId, itemId interface{}) *gomock.
 }
 
 // Delete mocks base method
-func (m *MockTodoItem) Delete(userId, itemId int) error {
+func (m *MockTodoItem) Delete(userId, itemId int, version *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockTodoItemMockRecorder) Delete(userId, itemId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, itemId, version)
+}
+
+// Restore mocks base method
+func (m *MockTodoItem) Restore(userId, itemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", userId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore
+func (mr *MockTodoItemMockRecorder) Restore(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoItem)(nil).Restore), userId, itemId)
+}
+
+// Update mocks base method
+func (m *MockTodoItem) Update(userId, itemId int, input todo.UpdateItemInput, version *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, itemId, input, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockTodoItemMockRecorder) Update(userId, itemId, input, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), userId, itemId, input, version)
+}
+
+// Patch mocks base method
+func (m *MockTodoItem) Patch(userId, itemId int, patch todo.Patch, version *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", userId, itemId, patch, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockTodoItemMockRecorder) Patch(userId, itemId, patch, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockTodoItem)(nil).Patch), userId, itemId, patch, version)
+}
+
+// Bulk mocks base method
+func (m *MockTodoItem) Bulk(userId int, input todo.BulkItemsInput) ([]todo.ItemOperationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", userId, input)
+	ret0, _ := ret[0].([]todo.ItemOperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk
+func (mr *MockTodoItemMockRecorder) Bulk(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockTodoItem)(nil).Bulk), userId, input)
+}
+
+// Move mocks base method
+func (m *MockTodoItem) Move(userId, itemId int, input todo.MoveItemInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userId, itemId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move
+func (mr *MockTodoItemMockRecorder) Move(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoItem)(nil).Move), userId, itemId, input)
+}
+
+// Archive mocks base method
+func (m *MockTodoItem) Archive(userId, itemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", userId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive
+func (mr *MockTodoItemMockRecorder) Archive(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockTodoItem)(nil).Archive), userId, itemId)
+}
+
+// Unarchive mocks base method
+func (m *MockTodoItem) Unarchive(userId, itemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", userId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unarchive indicates an expected call of Unarchive
+func (mr *MockTodoItemMockRecorder) Unarchive(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockTodoItem)(nil).Unarchive), userId, itemId)
+}
+
+// Copy mocks base method
+func (m *MockTodoItem) Copy(userId, itemId int, input todo.CopyItemInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", userId, itemId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy
+func (mr *MockTodoItemMockRecorder) Copy(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockTodoItem)(nil).Copy), userId, itemId, input)
+}
+
+// MockTag is a mock of Tag interface
+type MockTag struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagMockRecorder
+}
+
+// MockTagMockRecorder is the mock recorder for MockTag
+type MockTagMockRecorder struct {
+	mock *MockTag
+}
+
+// NewMockTag creates a new mock instance
+func NewMockTag(ctrl *gomock.Controller) *MockTag {
+	mock := &MockTag{ctrl: ctrl}
+	mock.recorder = &MockTagMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTag) EXPECT() *MockTagMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockTag) Create(userId int, tag todo.Tag) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, tag)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockTagMockRecorder) Create(userId, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTag)(nil).Create), userId, tag)
+}
+
+// GetAll mocks base method
+func (m *MockTag) GetAll(userId int) ([]todo.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]todo.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll
+func (mr *MockTagMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTag)(nil).GetAll), userId)
+}
+
+// GetById mocks base method
+func (m *MockTag) GetById(userId, tagId int) (todo.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, tagId)
+	ret0, _ := ret[0].(todo.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById
+func (mr *MockTagMockRecorder) GetById(userId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTag)(nil).GetById), userId, tagId)
+}
+
+// Delete mocks base method
+func (m *MockTag) Delete(userId, tagId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, itemId)
+	ret := m.ctrl.Call(m, "Delete", userId, tagId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete
-func (mr *MockTodoItemMockRecorder) Delete(userId, itemId interface{}) *gomock.Call {
+func (mr *MockTagMockRecorder) Delete(userId, tagId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, itemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTag)(nil).Delete), userId, tagId)
 }
 
 // Update mocks base method
-func (m *MockTodoItem) Update(userId, itemId int, input todo.UpdateItemInput) error {
+func (m *MockTag) Update(userId, tagId int, input todo.UpdateTagInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, itemId, input)
+	ret := m.ctrl.Call(m, "Update", userId, tagId, input)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update
-func (mr *MockTodoItemMockRecorder) Update(userId, itemId, input interface{}) *gomock.Call {
+func (mr *MockTagMockRecorder) Update(userId, tagId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTag)(nil).Update), userId, tagId, input)
+}
+
+// GetByItemId mocks base method
+func (m *MockTag) GetByItemId(userId, itemId int) ([]todo.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByItemId", userId, itemId)
+	ret0, _ := ret[0].([]todo.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByItemId indicates an expected call of GetByItemId
+func (mr *MockTagMockRecorder) GetByItemId(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByItemId", reflect.TypeOf((*MockTag)(nil).GetByItemId), userId, itemId)
+}
+
+// AddToItem mocks base method
+func (m *MockTag) AddToItem(userId, itemId, tagId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToItem", userId, itemId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToItem indicates an expected call of AddToItem
+func (mr *MockTagMockRecorder) AddToItem(userId, itemId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToItem", reflect.TypeOf((*MockTag)(nil).AddToItem), userId, itemId, tagId)
+}
+
+// RemoveFromItem mocks base method
+func (m *MockTag) RemoveFromItem(userId, itemId, tagId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromItem", userId, itemId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromItem indicates an expected call of RemoveFromItem
+func (mr *MockTagMockRecorder) RemoveFromItem(userId, itemId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromItem", reflect.TypeOf((*MockTag)(nil).RemoveFromItem), userId, itemId, tagId)
+}
+
+// MockTrash is a mock of Trash interface
+type MockTrash struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashMockRecorder
+}
+
+// MockTrashMockRecorder is the mock recorder for MockTrash
+type MockTrashMockRecorder struct {
+	mock *MockTrash
+}
+
+// NewMockTrash creates a new mock instance
+func NewMockTrash(ctrl *gomock.Controller) *MockTrash {
+	mock := &MockTrash{ctrl: ctrl}
+	mock.recorder = &MockTrashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTrash) EXPECT() *MockTrashMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockTrash) Get(userId int) (todo.Trash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userId)
+	ret0, _ := ret[0].(todo.Trash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockTrashMockRecorder) Get(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTrash)(nil).Get), userId)
+}
+
+// Purge mocks base method
+func (m *MockTrash) Purge(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge
+func (mr *MockTrashMockRecorder) Purge(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrash)(nil).Purge), before)
+}
+
+// MockIdempotency is a mock of Idempotency interface
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method
+func (m *MockIdempotency) Begin(userId int, key, requestHash string) (*todo.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", userId, key, requestHash)
+	ret0, _ := ret[0].(*todo.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin
+func (mr *MockIdempotencyMockRecorder) Begin(userId, key, requestHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotency)(nil).Begin), userId, key, requestHash)
+}
+
+// Complete mocks base method
+func (m *MockIdempotency) Complete(userId int, key string, statusCode int, headers todo.ResponseHeaders, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", userId, key, statusCode, headers, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete
+func (mr *MockIdempotencyMockRecorder) Complete(userId, key, statusCode, headers, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotency)(nil).Complete), userId, key, statusCode, headers, body)
+}
+
+// Release mocks base method
+func (m *MockIdempotency) Release(userId int, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release
+func (mr *MockIdempotencyMockRecorder) Release(userId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotency)(nil).Release), userId, key)
+}
+
+// MockActivity is a mock of Activity interface
+type MockActivity struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityMockRecorder
+}
+
+// MockActivityMockRecorder is the mock recorder for MockActivity
+type MockActivityMockRecorder struct {
+	mock *MockActivity
+}
+
+// NewMockActivity creates a new mock instance
+func NewMockActivity(ctrl *gomock.Controller) *MockActivity {
+	mock := &MockActivity{ctrl: ctrl}
+	mock.recorder = &MockActivityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockActivity) EXPECT() *MockActivityMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method
+func (m *MockActivity) GetAll(userId int, filter todo.ActivityFilter) ([]todo.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, filter)
+	ret0, _ := ret[0].([]todo.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll
+func (mr *MockActivityMockRecorder) GetAll(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockActivity)(nil).GetAll), userId, filter)
+}
+
+// GetByList mocks base method
+func (m *MockActivity) GetByList(userId, listId int, filter todo.ActivityFilter) ([]todo.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByList", userId, listId, filter)
+	ret0, _ := ret[0].([]todo.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByList indicates an expected call of GetByList
+func (mr *MockActivityMockRecorder) GetByList(userId, listId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByList", reflect.TypeOf((*MockActivity)(nil).GetByList), userId, listId, filter)
+}
+
+// MockUndo is a mock of Undo interface
+type MockUndo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoMockRecorder
+}
+
+// MockUndoMockRecorder is the mock recorder for MockUndo
+type MockUndoMockRecorder struct {
+	mock *MockUndo
+}
+
+// NewMockUndo creates a new mock instance
+func NewMockUndo(ctrl *gomock.Controller) *MockUndo {
+	mock := &MockUndo{ctrl: ctrl}
+	mock.recorder = &MockUndoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUndo) EXPECT() *MockUndoMockRecorder {
+	return m.recorder
+}
+
+// Undo mocks base method
+func (m *MockUndo) Undo(userId int, input todo.UndoInput) ([]todo.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", userId, input)
+	ret0, _ := ret[0].([]todo.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo
+func (mr *MockUndoMockRecorder) Undo(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockUndo)(nil).Undo), userId, input)
+}
+
+// MockWebhook is a mock of Webhook interface
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockWebhook) Create(userId int, webhook todo.Webhook) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, webhook)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockWebhookMockRecorder) Create(userId, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhook)(nil).Create), userId, webhook)
+}
+
+// GetAll mocks base method
+func (m *MockWebhook) GetAll(userId int) ([]todo.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]todo.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll
+func (mr *MockWebhookMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWebhook)(nil).GetAll), userId)
+}
+
+// GetById mocks base method
+func (m *MockWebhook) GetById(userId, webhookId int) (todo.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, webhookId)
+	ret0, _ := ret[0].(todo.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById
+func (mr *MockWebhookMockRecorder) GetById(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWebhook)(nil).GetById), userId, webhookId)
+}
+
+// Delete mocks base method
+func (m *MockWebhook) Delete(userId, webhookId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, webhookId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockWebhookMockRecorder) Delete(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), userId, webhookId)
+}
+
+// GetDeliveries mocks base method
+func (m *MockWebhook) GetDeliveries(userId, webhookId int) ([]todo.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", userId, webhookId)
+	ret0, _ := ret[0].([]todo.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries
+func (mr *MockWebhookMockRecorder) GetDeliveries(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), userId, webhookId)
+}
+
+// Replay mocks base method
+func (m *MockWebhook) Replay(userId, webhookId, deliveryId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", userId, webhookId, deliveryId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay
+func (mr *MockWebhookMockRecorder) Replay(userId, webhookId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockWebhook)(nil).Replay), userId, webhookId, deliveryId)
+}
+
+// DeliverPending mocks base method
+func (m *MockWebhook) DeliverPending() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverPending")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliverPending indicates an expected call of DeliverPending
+func (mr *MockWebhookMockRecorder) DeliverPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverPending", reflect.TypeOf((*MockWebhook)(nil).DeliverPending))
+}
+
+// MockStream is a mock of Stream interface
+type MockStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamMockRecorder
+}
+
+// MockStreamMockRecorder is the mock recorder for MockStream
+type MockStreamMockRecorder struct {
+	mock *MockStream
+}
+
+// NewMockStream creates a new mock instance
+func NewMockStream(ctrl *gomock.Controller) *MockStream {
+	mock := &MockStream{ctrl: ctrl}
+	mock.recorder = &MockStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStream) EXPECT() *MockStreamMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method
+func (m *MockStream) Subscribe(userId int) (<-chan struct{}, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userId)
+	ret0, _ := ret[0].(<-chan struct{})
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockStreamMockRecorder) Subscribe(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockStream)(nil).Subscribe), userId)
+}
+
+// Notify mocks base method
+func (m *MockStream) Notify(listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify
+func (mr *MockStreamMockRecorder) Notify(listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockStream)(nil).Notify), listId)
+}
+
+// NotifyAll mocks base method
+func (m *MockStream) NotifyAll() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyAll")
+}
+
+// NotifyAll indicates an expected call of NotifyAll
+func (mr *MockStreamMockRecorder) NotifyAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyAll", reflect.TypeOf((*MockStream)(nil).NotifyAll))
+}
+
+// GetLastId mocks base method
+func (m *MockStream) GetLastId(userId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastId", userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastId indicates an expected call of GetLastId
+func (mr *MockStreamMockRecorder) GetLastId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastId", reflect.TypeOf((*MockStream)(nil).GetLastId), userId)
+}
+
+// GetSince mocks base method
+func (m *MockStream) GetSince(userId, afterId int) ([]todo.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSince", userId, afterId)
+	ret0, _ := ret[0].([]todo.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSince indicates an expected call of GetSince
+func (mr *MockStreamMockRecorder) GetSince(userId, afterId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSince", reflect.TypeOf((*MockStream)(nil).GetSince), userId, afterId)
+}
+
+// MockExport is a mock of Export interface
+type MockExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportMockRecorder
+}
+
+// MockExportMockRecorder is the mock recorder for MockExport
+type MockExportMockRecorder struct {
+	mock *MockExport
+}
+
+// NewMockExport creates a new mock instance
+func NewMockExport(ctrl *gomock.Controller) *MockExport {
+	mock := &MockExport{ctrl: ctrl}
+	mock.recorder = &MockExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockExport) EXPECT() *MockExportMockRecorder {
+	return m.recorder
+}
+
+// GetList mocks base method
+func (m *MockExport) GetList(userId, listId int) (todo.ListExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", userId, listId)
+	ret0, _ := ret[0].(todo.ListExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList
+func (mr *MockExportMockRecorder) GetList(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockExport)(nil).GetList), userId, listId)
+}
+
+// ForEachList mocks base method
+func (m *MockExport) ForEachList(userId int, fn func(todo.ListExport) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachList", userId, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachList indicates an expected call of ForEachList
+func (mr *MockExportMockRecorder) ForEachList(userId, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachList", reflect.TypeOf((*MockExport)(nil).ForEachList), userId, fn)
+}
+
+// MockImport is a mock of Import interface
+type MockImport struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportMockRecorder
+}
+
+// MockImportMockRecorder is the mock recorder for MockImport
+type MockImportMockRecorder struct {
+	mock *MockImport
+}
+
+// NewMockImport creates a new mock instance
+func NewMockImport(ctrl *gomock.Controller) *MockImport {
+	mock := &MockImport{ctrl: ctrl}
+	mock.recorder = &MockImportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockImport) EXPECT() *MockImportMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method
+func (m *MockImport) Import(userId int, lists []todo.ListExport, dryRun bool) ([]todo.ImportedList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", userId, lists, dryRun)
+	ret0, _ := ret[0].([]todo.ImportedList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import
+func (mr *MockImportMockRecorder) Import(userId, lists, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockImport)(nil).Import), userId, lists, dryRun)
+}
+
+// MockCalendar is a mock of Calendar interface
+type MockCalendar struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarMockRecorder
+}
+
+// MockCalendarMockRecorder is the mock recorder for MockCalendar
+type MockCalendarMockRecorder struct {
+	mock *MockCalendar
+}
+
+// NewMockCalendar creates a new mock instance
+func NewMockCalendar(ctrl *gomock.Controller) *MockCalendar {
+	mock := &MockCalendar{ctrl: ctrl}
+	mock.recorder = &MockCalendarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCalendar) EXPECT() *MockCalendarMockRecorder {
+	return m.recorder
+}
+
+// CreateToken mocks base method
+func (m *MockCalendar) CreateToken(userId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", userId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken
+func (mr *MockCalendarMockRecorder) CreateToken(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockCalendar)(nil).CreateToken), userId)
+}
+
+// DeleteToken mocks base method
+func (m *MockCalendar) DeleteToken(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteToken indicates an expected call of DeleteToken
+func (mr *MockCalendarMockRecorder) DeleteToken(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockCalendar)(nil).DeleteToken), userId)
+}
+
+// GetUserId mocks base method
+func (m *MockCalendar) GetUserId(token string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserId", token)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserId indicates an expected call of GetUserId
+func (mr *MockCalendarMockRecorder) GetUserId(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserId", reflect.TypeOf((*MockCalendar)(nil).GetUserId), token)
+}
+
+// GetDueItems mocks base method
+func (m *MockCalendar) GetDueItems(userId int) ([]todo.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueItems", userId)
+	ret0, _ := ret[0].([]todo.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueItems indicates an expected call of GetDueItems
+func (mr *MockCalendarMockRecorder) GetDueItems(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueItems", reflect.TypeOf((*MockCalendar)(nil).GetDueItems), userId)
+}
+
+// GetLists mocks base method
+func (m *MockCalendar) GetLists(userId int) ([]todo.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", userId)
+	ret0, _ := ret[0].([]todo.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists
+func (mr *MockCalendarMockRecorder) GetLists(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockCalendar)(nil).GetLists), userId)
+}
+
+// GetList mocks base method
+func (m *MockCalendar) GetList(userId, listId int) (todo.ListExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", userId, listId)
+	ret0, _ := ret[0].(todo.ListExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList
+func (mr *MockCalendarMockRecorder) GetList(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockCalendar)(nil).GetList), userId, listId)
+}
+
+// MockOutbox is a mock of Outbox interface
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// PublishPending mocks base method
+func (m *MockOutbox) PublishPending() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPending")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishPending indicates an expected call of PublishPending
+func (mr *MockOutboxMockRecorder) PublishPending() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), userId, itemId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPending", reflect.TypeOf((*MockOutbox)(nil).PublishPending))
 }
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+const (
+	outboxBatchSize  = 100
+	outboxLease      = time.Minute
+	outboxMaxBackoff = time.Hour
+)
+
+// EventPublisher passes the events of the outbox on, e.g. to a message broker or a search index.
+// An event is published at least once: it's published again if recording its publication fails,
+// so publishers have to tolerate duplicates, which can be recognized by the event's id.
+type EventPublisher interface {
+	Publish(event todo.OutboxEvent) error
+}
+
+// LogPublisher writes the events to the log. It's the publisher used unless another one is configured.
+type LogPublisher struct{}
+
+func (p LogPublisher) Publish(event todo.OutboxEvent) error {
+	logrus.WithFields(logrus.Fields{
+		"id":      event.Id,
+		"event":   event.Event,
+		"list_id": event.ListId,
+		"item_id": event.ItemId,
+	}).Info(string(event.Payload))
+
+	return nil
+}
+
+type OutboxService struct {
+	repo      repository.Outbox
+	publisher EventPublisher
+	backoff   time.Duration
+}
+
+func NewOutboxService(repo repository.Outbox, publisher EventPublisher, backoff time.Duration) *OutboxService {
+	if publisher == nil {
+		publisher = LogPublisher{}
+	}
+
+	return &OutboxService{repo: repo, publisher: publisher, backoff: backoff}
+}
+
+// PublishPending publishes the events that are due until there are none left. Events that fail
+// to be published are retried with exponential backoff, and later events are published meanwhile.
+func (s *OutboxService) PublishPending() error {
+	for {
+		events, err := s.repo.Claim(outboxBatchSize, outboxLease)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := s.publish(event); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *OutboxService) publish(event todo.OutboxEvent) error {
+	if err := s.publisher.Publish(event); err != nil {
+		nextAttemptAt := time.Now().Add(backoffDelay(s.backoff, event.Attempts+1, outboxMaxBackoff))
+		return s.repo.Fail(event.Id, err.Error(), nextAttemptAt)
+	}
+
+	return s.repo.MarkPublished(event.Id)
+}
+
+// addToOutbox writes the events of the activity to the outbox within the transaction
+// the activity is recorded in, so the events of a change are published only if it was committed.
+func addToOutbox(repos *repository.Repository, activity todo.Activity) error {
+	for _, event := range webhookEvents(activity) {
+		payload, err := json.Marshal(todo.WebhookEvent{
+			Event:      event,
+			ListId:     activity.ListId,
+			ItemId:     activity.ItemId,
+			ActorId:    activity.ActorId,
+			Changes:    activity.Changes,
+			OccurredAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = repos.Outbox.Add(todo.OutboxEvent{
+			Event:   event,
+			ListId:  activity.ListId,
+			ItemId:  activity.ItemId,
+			Payload: payload,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/zhashkevych/todo-app"
+)
+
+// applyPatch applies the patch to the JSON representation of current and decodes the result
+// into patched, which must point to a zero value of the same type. Fields removed or set to null
+// by the patch keep their zero values. Adding fields that current doesn't have is an error.
+func applyPatch(patch todo.Patch, current interface{}, patched interface{}) error {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	var result []byte
+	switch patch.Type {
+	case todo.MergePatch:
+		result, err = jsonpatch.MergePatch(doc, patch.Document)
+	case todo.JSONPatch:
+		var operations jsonpatch.Patch
+		operations, err = jsonpatch.DecodePatch(patch.Document)
+		if err == nil {
+			result, err = operations.Apply(doc)
+		}
+	default:
+		err = errors.New("unknown patch type")
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", todo.ErrInvalidPatch, err)
+	}
+
+	var known, fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &known); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(result, &fields); err != nil {
+		return fmt.Errorf("%w: %s", todo.ErrInvalidPatch, err)
+	}
+
+	for field := range fields {
+		if _, ok := known[field]; !ok {
+			return fmt.Errorf("%w: unknown field %q", todo.ErrInvalidPatch, field)
+		}
+	}
+
+	if err := json.Unmarshal(result, patched); err != nil {
+		return fmt.Errorf("%w: %s", todo.ErrInvalidPatch, err)
+	}
+
+	return nil
+}
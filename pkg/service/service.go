@@ -1,6 +1,8 @@
 package service
 
 import (
+	"time"
+
 	"github.com/zhashkevych/todo-app"
 	"github.com/zhashkevych/todo-app/pkg/repository"
 )
@@ -11,34 +13,156 @@ type Authorization interface {
 	CreateUser(user todo.User) (int, error)
 	GenerateToken(username, password string) (string, error)
 	ParseToken(token string) (int, error)
+	GetUserById(userId int) (todo.User, error)
 }
 
 type TodoList interface {
 	Create(userId int, list todo.TodoList) (int, error)
-	GetAll(userId int) ([]todo.TodoList, error)
+	GetAll(userId int, filter todo.ListFilter) ([]todo.TodoList, int, error)
 	GetById(userId, listId int) (todo.TodoList, error)
-	Delete(userId, listId int) error
-	Update(userId, listId int, input todo.UpdateListInput) error
+	Delete(userId, listId int, version *int) error
+	Restore(userId, listId int) error
+	Update(userId, listId int, input todo.UpdateListInput, version *int) error
+	Patch(userId, listId int, patch todo.Patch, version *int) error
+	Move(userId, listId int, input todo.MoveListInput) error
+	Archive(userId, listId int) error
+	Unarchive(userId, listId int) error
+	Duplicate(userId, listId int, input todo.DuplicateListInput) (int, error)
+	Instantiate(userId, listId int, input todo.InstantiateListInput) (int, error)
 }
 
 type TodoItem interface {
 	Create(userId, listId int, item todo.TodoItem) (int, error)
-	GetAll(userId, listId int) ([]todo.TodoItem, error)
+	CreateChild(userId, parentId int, item todo.TodoItem) (int, error)
+	GetAll(userId, listId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error)
+	GetAllByUser(userId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error)
+	GetAllByLists(userId int, listIds []int) ([]todo.TodoItem, error)
+	GetChildren(userId, itemId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error)
 	GetById(userId, itemId int) (todo.TodoItem, error)
-	Delete(userId, itemId int) error
-	Update(userId, itemId int, input todo.UpdateItemInput) error
+	Delete(userId, itemId int, version *int) error
+	Restore(userId, itemId int) error
+	Update(userId, itemId int, input todo.UpdateItemInput, version *int) error
+	Patch(userId, itemId int, patch todo.Patch, version *int) error
+	Bulk(userId int, input todo.BulkItemsInput) ([]todo.ItemOperationResult, error)
+	Move(userId, itemId int, input todo.MoveItemInput) error
+	Archive(userId, itemId int) error
+	Unarchive(userId, itemId int) error
+	Copy(userId, itemId int, input todo.CopyItemInput) (int, error)
+}
+
+type Tag interface {
+	Create(userId int, tag todo.Tag) (int, error)
+	GetAll(userId int) ([]todo.Tag, error)
+	GetById(userId, tagId int) (todo.Tag, error)
+	Delete(userId, tagId int) error
+	Update(userId, tagId int, input todo.UpdateTagInput) error
+	GetByItemId(userId, itemId int) ([]todo.Tag, error)
+	AddToItem(userId, itemId, tagId int) error
+	RemoveFromItem(userId, itemId, tagId int) error
+}
+
+type Trash interface {
+	Get(userId int) (todo.Trash, error)
+	Purge(before time.Time) error
+}
+
+type Idempotency interface {
+	Begin(userId int, key, requestHash string) (*todo.IdempotencyKey, error)
+	Complete(userId int, key string, statusCode int, headers todo.ResponseHeaders, body []byte) error
+	Release(userId int, key string) error
+}
+
+type Activity interface {
+	GetAll(userId int, filter todo.ActivityFilter) ([]todo.Activity, error)
+	GetByList(userId, listId int, filter todo.ActivityFilter) ([]todo.Activity, error)
+}
+
+type Undo interface {
+	Undo(userId int, input todo.UndoInput) ([]todo.Activity, error)
+}
+
+type Webhook interface {
+	Create(userId int, webhook todo.Webhook) (int, error)
+	GetAll(userId int) ([]todo.Webhook, error)
+	GetById(userId, webhookId int) (todo.Webhook, error)
+	Delete(userId, webhookId int) error
+	GetDeliveries(userId, webhookId int) ([]todo.WebhookDelivery, error)
+	Replay(userId, webhookId, deliveryId int) (int, error)
+	DeliverPending() error
+}
+
+type Stream interface {
+	Subscribe(userId int) (<-chan struct{}, func())
+	Notify(listId int) error
+	NotifyAll()
+	GetLastId(userId int) (int, error)
+	GetSince(userId, afterId int) ([]todo.Activity, error)
+}
+
+type Export interface {
+	GetList(userId, listId int) (todo.ListExport, error)
+	ForEachList(userId int, fn func(list todo.ListExport) error) error
+}
+
+type Import interface {
+	Import(userId int, lists []todo.ListExport, dryRun bool) ([]todo.ImportedList, error)
+}
+
+type Calendar interface {
+	CreateToken(userId int) (string, error)
+	DeleteToken(userId int) error
+	GetUserId(token string) (int, error)
+	GetDueItems(userId int) ([]todo.TodoItem, error)
+	GetLists(userId int) ([]todo.TodoList, error)
+	GetList(userId, listId int) (todo.ListExport, error)
+}
+
+type Outbox interface {
+	PublishPending() error
+}
+
+type Config struct {
+	IdempotencyTTL     time.Duration
+	UndoWindow         time.Duration
+	WebhookTimeout     time.Duration
+	WebhookMaxAttempts int
+	WebhookBackoff     time.Duration
+	OutboxBackoff      time.Duration
+	EventPublisher     EventPublisher
 }
 
 type Service struct {
 	Authorization
 	TodoList
 	TodoItem
+	Tag
+	Trash
+	Idempotency
+	Activity
+	Undo
+	Webhook
+	Stream
+	Outbox
+	Export
+	Import
+	Calendar
 }
 
-func NewService(repos *repository.Repository) *Service {
+func NewService(repos *repository.Repository, cfg Config) *Service {
 	return &Service{
 		Authorization: NewAuthService(repos.Authorization),
-		TodoList:      NewTodoListService(repos.TodoList),
-		TodoItem:      NewTodoItemService(repos.TodoItem, repos.TodoList),
+		TodoList:      NewTodoListService(repos.TodoList, repos.TodoItem, repos.Transactor),
+		TodoItem:      NewTodoItemService(repos.TodoItem, repos.TodoList, repos.Transactor),
+		Tag:           NewTagService(repos.Tag, repos.TodoItem),
+		Trash:         NewTrashService(repos.Trash),
+		Idempotency:   NewIdempotencyService(repos.Idempotency, cfg.IdempotencyTTL),
+		Activity:      NewActivityService(repos.Activity),
+		Undo:          NewUndoService(repos.Transactor, cfg.UndoWindow),
+		Stream:        NewStreamService(repos.Activity),
+		Webhook:       NewWebhookService(repos.Webhook, repos.TodoList, cfg.WebhookTimeout, cfg.WebhookMaxAttempts, cfg.WebhookBackoff),
+		Export:        NewExportService(repos.TodoList, repos.TodoItem, repos.Tag),
+		Import:        NewImportService(repos.Transactor),
+		Calendar:      NewCalendarService(repos.Calendar, repos.TodoList, repos.TodoItem),
+		Outbox:        NewOutboxService(repos.Outbox, cfg.EventPublisher, cfg.OutboxBackoff),
 	}
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+const streamBatchSize = 100
+
+// StreamService wakes up the activity streams of the users a list is shared with
+// when activity of the list is recorded by any instance of the app.
+type StreamService struct {
+	repo repository.Activity
+
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+}
+
+func NewStreamService(repo repository.Activity) *StreamService {
+	return &StreamService{repo: repo, subscribers: make(map[int]map[chan struct{}]struct{})}
+}
+
+// Subscribe returns a channel that receives a value when there may be new activity for the user
+// and a function that cancels the subscription. Wake-ups are coalesced while the stream is busy.
+func (s *StreamService) Subscribe(userId int) (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	if s.subscribers[userId] == nil {
+		s.subscribers[userId] = make(map[chan struct{}]struct{})
+	}
+	s.subscribers[userId][wake] = struct{}{}
+	s.mu.Unlock()
+
+	return wake, func() {
+		s.mu.Lock()
+		delete(s.subscribers[userId], wake)
+		if len(s.subscribers[userId]) == 0 {
+			delete(s.subscribers, userId)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Notify wakes up the streams of the users the list is shared with.
+func (s *StreamService) Notify(listId int) error {
+	userIds, err := s.repo.GetListUsers(listId)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, userId := range userIds {
+		for wake := range s.subscribers[userId] {
+			wakeUp(wake)
+		}
+	}
+
+	return nil
+}
+
+// NotifyAll wakes up all streams, for example after notifications might have been missed.
+func (s *StreamService) NotifyAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, subscribers := range s.subscribers {
+		for wake := range subscribers {
+			wakeUp(wake)
+		}
+	}
+}
+
+func wakeUp(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+		// already woken up
+	}
+}
+
+func (s *StreamService) GetLastId(userId int) (int, error) {
+	return s.repo.GetLastId(userId)
+}
+
+// GetSince returns the next batch of activity of the user's lists recorded after afterId.
+func (s *StreamService) GetSince(userId, afterId int) ([]todo.Activity, error) {
+	return s.repo.GetSince(userId, afterId, streamBatchSize)
+}
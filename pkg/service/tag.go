@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+type TagService struct {
+	repo     repository.Tag
+	itemRepo repository.TodoItem
+}
+
+func NewTagService(repo repository.Tag, itemRepo repository.TodoItem) *TagService {
+	return &TagService{repo: repo, itemRepo: itemRepo}
+}
+
+func (s *TagService) Create(userId int, tag todo.Tag) (int, error) {
+	return s.repo.Create(userId, tag)
+}
+
+func (s *TagService) GetAll(userId int) ([]todo.Tag, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *TagService) GetById(userId, tagId int) (todo.Tag, error) {
+	return s.repo.GetById(userId, tagId)
+}
+
+func (s *TagService) Delete(userId, tagId int) error {
+	return s.repo.Delete(userId, tagId)
+}
+
+func (s *TagService) Update(userId, tagId int, input todo.UpdateTagInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.Update(userId, tagId, input)
+}
+
+func (s *TagService) GetByItemId(userId, itemId int) ([]todo.Tag, error) {
+	return s.repo.GetByItemId(userId, itemId)
+}
+
+func (s *TagService) AddToItem(userId, itemId, tagId int) error {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		// item does not exists or does not belongs to user
+		return err
+	}
+
+	if _, err := s.repo.GetById(userId, tagId); err != nil {
+		return err
+	}
+
+	return s.repo.AddToItem(userId, itemId, tagId)
+}
+
+func (s *TagService) RemoveFromItem(userId, itemId, tagId int) error {
+	return s.repo.RemoveFromItem(userId, itemId, tagId)
+}
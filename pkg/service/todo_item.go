@@ -1,17 +1,34 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/rank"
 	"github.com/zhashkevych/todo-app/pkg/repository"
 )
 
+// patchableItem holds the fields of an item that can be changed by a patch.
+type patchableItem struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Done        bool   `json:"done"`
+	DueDate     string `json:"due_date"`
+}
+
+// errBatchFailed rolls back the transaction of an atomic batch.
+var errBatchFailed = errors.New("batch failed")
+
 type TodoItemService struct {
-	repo repository.TodoItem
-	listRepo repository.TodoList
+	repo       repository.TodoItem
+	listRepo   repository.TodoList
+	transactor repository.Transactor
 }
 
-func NewTodoItemService(repo repository.TodoItem, listRepo repository.TodoList) *TodoItemService {
-	return &TodoItemService{repo: repo, listRepo: listRepo}
+func NewTodoItemService(repo repository.TodoItem, listRepo repository.TodoList, transactor repository.Transactor) *TodoItemService {
+	return &TodoItemService{repo: repo, listRepo: listRepo, transactor: transactor}
 }
 
 func (s *TodoItemService) Create(userId, listId int, item todo.TodoItem) (int, error) {
@@ -21,21 +38,419 @@ func (s *TodoItemService) Create(userId, listId int, item todo.TodoItem) (int, e
 		return 0, err
 	}
 
-	return s.repo.Create(listId, item)
+	last, err := s.repo.GetLastPosition(listId, nil)
+	if err != nil {
+		return 0, err
+	}
+	item.Position = rank.After(last)
+
+	var id int
+	err = record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		var err error
+		if id, err = repos.TodoItem.Create(userId, listId, item); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: listId, ItemId: &id, Action: todo.ActivityCreate, Changes: itemFields(item)}, nil
+	})
+
+	return id, err
+}
+
+func (s *TodoItemService) CreateChild(userId, parentId int, item todo.TodoItem) (int, error) {
+	parent, err := s.repo.GetById(userId, parentId)
+	if err != nil {
+		// parent item does not exists or does not belongs to user
+		return 0, err
+	}
+
+	last, err := s.repo.GetLastPosition(parent.ListId, &parent.Id)
+	if err != nil {
+		return 0, err
+	}
+	item.Position = rank.After(last)
+	item.ParentId = &parent.Id
+
+	var id int
+	err = record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		var err error
+		if id, err = repos.TodoItem.CreateChild(userId, parentId, item); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: parent.ListId, ItemId: &id, Action: todo.ActivityCreate, Changes: itemFields(item)}, nil
+	})
+
+	return id, err
+}
+
+func (s *TodoItemService) GetAll(userId, listId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
+	return s.repo.GetAll(userId, listId, filter)
 }
 
-func (s *TodoItemService) GetAll(userId, listId int) ([]todo.TodoItem, error) {
-	return s.repo.GetAll(userId, listId)
+func (s *TodoItemService) GetAllByUser(userId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
+	return s.repo.GetAllByUser(userId, filter)
+}
+
+func (s *TodoItemService) GetAllByLists(userId int, listIds []int) ([]todo.TodoItem, error) {
+	return s.repo.GetAllByLists(userId, listIds)
+}
+
+func (s *TodoItemService) GetChildren(userId, itemId int, filter todo.ItemFilter) ([]todo.TodoItem, int, error) {
+	return s.repo.GetChildren(userId, itemId, filter)
 }
 
 func (s *TodoItemService) GetById(userId, itemId int) (todo.TodoItem, error) {
-	return s.repo.GetById(userId, itemId)
+	item, err := s.repo.GetById(userId, itemId)
+	if err != nil {
+		return item, err
+	}
+
+	progress, err := s.repo.GetProgress(itemId)
+	if err != nil {
+		return item, err
+	}
+	item.Progress = &progress
+
+	return item, nil
 }
 
-func (s *TodoItemService) Delete(userId, itemId int) error {
-	return s.repo.Delete(userId, itemId)
+func (s *TodoItemService) Delete(userId, itemId int, version *int) error {
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		item, err := repos.TodoItem.GetById(userId, itemId)
+		if err != nil {
+			return todo.Activity{}, err
+		}
+
+		if err := repos.TodoItem.Delete(userId, itemId, version); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: item.ListId, ItemId: &itemId, Action: todo.ActivityDelete}, nil
+	})
 }
 
-func (s *TodoItemService) Update(userId, itemId int, input todo.UpdateItemInput) error {
-	return s.repo.Update(userId, itemId, input)
-}
\ No newline at end of file
+func (s *TodoItemService) Restore(userId, itemId int) error {
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		if err := repos.TodoItem.Restore(userId, itemId); err != nil {
+			return todo.Activity{}, err
+		}
+
+		item, err := repos.TodoItem.GetById(userId, itemId)
+		if err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: item.ListId, ItemId: &itemId, Action: todo.ActivityRestore}, nil
+	})
+}
+
+func (s *TodoItemService) Update(userId, itemId int, input todo.UpdateItemInput, version *int) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		item, err := repos.TodoItem.GetById(userId, itemId)
+		if err != nil {
+			return todo.Activity{}, err
+		}
+
+		return s.update(repos, userId, item, input, version)
+	})
+}
+
+// Patch applies the patch to the item. The changed fields are written only
+// if the item hasn't been modified since it was read.
+func (s *TodoItemService) Patch(userId, itemId int, patch todo.Patch, version *int) error {
+	item, err := s.repo.GetById(userId, itemId)
+	if err != nil {
+		return err
+	}
+
+	if version != nil && *version != item.Version {
+		return todo.ErrVersionMismatch
+	}
+
+	current := patchableItem{Title: item.Title, Description: item.Description, Done: item.Done, DueDate: stringValue(item.DueDate)}
+	var patched patchableItem
+	if err := applyPatch(patch, current, &patched); err != nil {
+		return err
+	}
+
+	if patched.Title == "" {
+		return fmt.Errorf("%w: title can't be empty", todo.ErrInvalidPatch)
+	}
+
+	var input todo.UpdateItemInput
+	if patched.Title != current.Title {
+		input.Title = &patched.Title
+	}
+	if patched.Description != current.Description {
+		input.Description = &patched.Description
+	}
+	if patched.Done != current.Done {
+		input.Done = &patched.Done
+	}
+	if patched.DueDate != current.DueDate {
+		if _, err := time.Parse(todo.DateLayout, patched.DueDate); patched.DueDate != "" && err != nil {
+			return fmt.Errorf("%w: invalid due_date %q", todo.ErrInvalidPatch, patched.DueDate)
+		}
+		input.DueDate = &patched.DueDate
+	}
+
+	if input.Validate() != nil {
+		return nil
+	}
+
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		return s.update(repos, userId, item, input, &item.Version)
+	})
+}
+
+// update applies the input to the item and describes the change.
+func (s *TodoItemService) update(repos *repository.Repository, userId int, item todo.TodoItem,
+	input todo.UpdateItemInput, version *int) (todo.Activity, error) {
+	if err := repos.TodoItem.Update(userId, item.Id, input, version); err != nil {
+		return todo.Activity{}, err
+	}
+
+	return todo.Activity{ListId: item.ListId, ItemId: &item.Id, Action: todo.ActivityUpdate, Changes: itemChanges(item, input)}, nil
+}
+
+func (s *TodoItemService) Move(userId, itemId int, input todo.MoveItemInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	item, err := s.repo.GetById(userId, itemId)
+	if err != nil {
+		return err
+	}
+	moved := item
+
+	if input.ListId != nil && (*input.ListId != item.ListId || item.ParentId != nil) {
+		if _, err := s.listRepo.GetById(userId, *input.ListId); err != nil {
+			// target list does not exists or does not belongs to user
+			return err
+		}
+
+		moved.ListId, moved.ParentId = *input.ListId, nil
+		if moved.Position, err = s.getPosition(userId, moved, input); err != nil {
+			return err
+		}
+
+		return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+			if err := repos.TodoItem.MoveToList(userId, itemId, moved.ListId, nil, moved.Position); err != nil {
+				return todo.Activity{}, err
+			}
+
+			return todo.Activity{ListId: moved.ListId, ItemId: &itemId, Action: todo.ActivityMove, Changes: moveChanges(item, moved)}, nil
+		})
+	}
+
+	if moved.Position, err = s.getPosition(userId, moved, input); err != nil {
+		return err
+	}
+
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		if err := repos.TodoItem.UpdatePosition(userId, itemId, moved.Position); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: moved.ListId, ItemId: &itemId, Action: todo.ActivityMove, Changes: moveChanges(item, moved)}, nil
+	})
+}
+
+func moveChanges(item, moved todo.TodoItem) todo.Changes {
+	changes := make(todo.Changes)
+	addChange(changes, "list_id", item.ListId, moved.ListId)
+	addChange(changes, "parent_id", intValue(item.ParentId), intValue(moved.ParentId))
+	addChange(changes, "position", item.Position, moved.Position)
+
+	return changes
+}
+
+func (s *TodoItemService) Archive(userId, itemId int) error {
+	return s.setArchived(userId, itemId, true)
+}
+
+func (s *TodoItemService) Unarchive(userId, itemId int) error {
+	return s.setArchived(userId, itemId, false)
+}
+
+func (s *TodoItemService) setArchived(userId, itemId int, archived bool) error {
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		item, err := repos.TodoItem.GetById(userId, itemId)
+		if err != nil {
+			return todo.Activity{}, err
+		}
+
+		if err := repos.TodoItem.SetArchived(userId, itemId, archived); err != nil {
+			return todo.Activity{}, err
+		}
+
+		changes := make(todo.Changes)
+		addChange(changes, "archived", item.Archived, archived)
+		return todo.Activity{ListId: item.ListId, ItemId: &itemId, Action: todo.ActivityUpdate, Changes: changes}, nil
+	})
+}
+
+func (s *TodoItemService) Copy(userId, itemId int, input todo.CopyItemInput) (int, error) {
+	item, err := s.repo.GetById(userId, itemId)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := s.listRepo.GetById(userId, input.ListId); err != nil {
+		// target list does not exists or does not belongs to user
+		return 0, err
+	}
+
+	last, err := s.repo.GetLastPosition(input.ListId, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	item.ParentId = nil
+
+	var id int
+	err = record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		var err error
+		if id, err = repos.TodoItem.Copy(userId, itemId, input.ListId, rank.After(last)); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: input.ListId, ItemId: &id, Action: todo.ActivityCreate, Changes: itemFields(item)}, nil
+	})
+
+	return id, err
+}
+
+// getPosition calculates the new position of item among its siblings
+// next to the anchor from input or at the end if there is no anchor.
+func (s *TodoItemService) getPosition(userId int, item todo.TodoItem, input todo.MoveItemInput) (string, error) {
+	if input.AfterId != nil {
+		anchor, err := s.getSibling(userId, item, *input.AfterId)
+		if err != nil {
+			return "", err
+		}
+
+		next, err := s.repo.GetPositionAfter(anchor.ListId, anchor.ParentId, anchor.Position)
+		if err != nil {
+			return "", err
+		}
+
+		return rank.Between(anchor.Position, next)
+	}
+
+	if input.BeforeId != nil {
+		anchor, err := s.getSibling(userId, item, *input.BeforeId)
+		if err != nil {
+			return "", err
+		}
+
+		prev, err := s.repo.GetPositionBefore(anchor.ListId, anchor.ParentId, anchor.Position)
+		if err != nil {
+			return "", err
+		}
+
+		return rank.Between(prev, anchor.Position)
+	}
+
+	last, err := s.repo.GetLastPosition(item.ListId, item.ParentId)
+	if err != nil {
+		return "", err
+	}
+
+	return rank.After(last), nil
+}
+
+// getSibling loads the item with siblingId and makes sure
+// it shares the list and the parent with item.
+func (s *TodoItemService) getSibling(userId int, item todo.TodoItem, siblingId int) (todo.TodoItem, error) {
+	sibling, err := s.repo.GetById(userId, siblingId)
+	if err != nil {
+		return sibling, err
+	}
+
+	if sibling.ListId != item.ListId || !sameParent(sibling.ParentId, item.ParentId) {
+		return sibling, errors.New("items are not siblings")
+	}
+
+	return sibling, nil
+}
+
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// Bulk runs the operations in one transaction, each of them in its own savepoint,
+// and reports the outcome of every operation.
+func (s *TodoItemService) Bulk(userId int, input todo.BulkItemsInput) ([]todo.ItemOperationResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	results := make([]todo.ItemOperationResult, len(input.Operations))
+	for i := range results {
+		results[i].Status = todo.OperationStatusSkipped
+	}
+
+	err := s.transactor.WithinTransaction(func(repos *repository.Repository) error {
+		for i, operation := range input.Operations {
+			var id int
+			err := repos.WithinTransaction(func(repos *repository.Repository) error {
+				var err error
+				id, err = NewTodoItemService(repos.TodoItem, repos.TodoList, repos.Transactor).apply(userId, operation)
+				return err
+			})
+			if err != nil {
+				results[i] = todo.ItemOperationResult{Status: todo.OperationStatusFailed, Id: operation.Id, Error: err.Error()}
+				if input.Atomic {
+					return errBatchFailed
+				}
+				continue
+			}
+
+			results[i] = todo.ItemOperationResult{Status: todo.OperationStatusOk, Id: id}
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errBatchFailed) {
+		for i := range results {
+			if results[i].Status == todo.OperationStatusOk {
+				results[i].Status = todo.OperationStatusRolledBack
+			}
+		}
+		return results, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// apply runs a single operation of a bulk request and returns the id of the affected item.
+func (s *TodoItemService) apply(userId int, operation todo.ItemOperation) (int, error) {
+	switch operation.Op {
+	case todo.OperationCreate:
+		return s.Create(userId, operation.ListId, *operation.Item)
+	case todo.OperationUpdate:
+		return operation.Id, s.Update(userId, operation.Id, *operation.Update, operation.Version)
+	case todo.OperationDelete:
+		return operation.Id, s.Delete(userId, operation.Id, operation.Version)
+	case todo.OperationMove:
+		return operation.Id, s.Move(userId, operation.Id, *operation.Move)
+	default:
+		return 0, fmt.Errorf("unknown operation %q", operation.Op)
+	}
+}
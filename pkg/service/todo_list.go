@@ -1,38 +1,312 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/rank"
 	"github.com/zhashkevych/todo-app/pkg/repository"
 )
 
+// patchableList holds the fields of a list that can be changed by a patch.
+type patchableList struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	IsTemplate  bool   `json:"is_template"`
+}
+
 type TodoListService struct {
-	repo repository.TodoList
+	repo       repository.TodoList
+	itemRepo   repository.TodoItem
+	transactor repository.Transactor
 }
 
-func NewTodoListService(repo repository.TodoList) *TodoListService {
-	return &TodoListService{repo: repo}
+func NewTodoListService(repo repository.TodoList, itemRepo repository.TodoItem, transactor repository.Transactor) *TodoListService {
+	return &TodoListService{repo: repo, itemRepo: itemRepo, transactor: transactor}
 }
 
 func (s *TodoListService) Create(userId int, list todo.TodoList) (int, error) {
-	return s.repo.Create(userId, list)
+	last, err := s.repo.GetLastPosition(userId)
+	if err != nil {
+		return 0, err
+	}
+	list.Position = rank.After(last)
+
+	var id int
+	err = record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		var err error
+		if id, err = repos.TodoList.Create(userId, list); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: id, Action: todo.ActivityCreate, Changes: listFields(list)}, nil
+	})
+
+	return id, err
 }
 
-func (s *TodoListService) GetAll(userId int) ([]todo.TodoList, error) {
-	return s.repo.GetAll(userId)
+func (s *TodoListService) GetAll(userId int, filter todo.ListFilter) ([]todo.TodoList, int, error) {
+	return s.repo.GetAll(userId, filter)
 }
 
 func (s *TodoListService) GetById(userId, listId int) (todo.TodoList, error) {
 	return s.repo.GetById(userId, listId)
 }
 
-func (s *TodoListService) Delete(userId, listId int) error {
-	return s.repo.Delete(userId, listId)
+func (s *TodoListService) Delete(userId, listId int, version *int) error {
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		if _, err := repos.TodoList.GetById(userId, listId); err != nil {
+			return todo.Activity{}, err
+		}
+
+		if err := repos.TodoList.Delete(userId, listId, version); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: listId, Action: todo.ActivityDelete}, nil
+	})
+}
+
+func (s *TodoListService) Restore(userId, listId int) error {
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		if err := repos.TodoList.Restore(userId, listId); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: listId, Action: todo.ActivityRestore}, nil
+	})
+}
+
+func (s *TodoListService) Update(userId, listId int, input todo.UpdateListInput, version *int) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		list, err := repos.TodoList.GetById(userId, listId)
+		if err != nil {
+			return todo.Activity{}, err
+		}
+
+		return s.update(repos, userId, list, input, version)
+	})
+}
+
+// Patch applies the patch to the list. The changed fields are written only
+// if the list hasn't been modified since it was read.
+func (s *TodoListService) Patch(userId, listId int, patch todo.Patch, version *int) error {
+	list, err := s.repo.GetById(userId, listId)
+	if err != nil {
+		return err
+	}
+
+	if version != nil && *version != list.Version {
+		return todo.ErrVersionMismatch
+	}
+
+	current := patchableList{Title: list.Title, Description: list.Description, IsTemplate: list.IsTemplate}
+	var patched patchableList
+	if err := applyPatch(patch, current, &patched); err != nil {
+		return err
+	}
+
+	if patched.Title == "" {
+		return fmt.Errorf("%w: title can't be empty", todo.ErrInvalidPatch)
+	}
+
+	var input todo.UpdateListInput
+	if patched.Title != current.Title {
+		input.Title = &patched.Title
+	}
+	if patched.Description != current.Description {
+		input.Description = &patched.Description
+	}
+	if patched.IsTemplate != current.IsTemplate {
+		input.IsTemplate = &patched.IsTemplate
+	}
+
+	if input.Validate() != nil {
+		return nil
+	}
+
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		return s.update(repos, userId, list, input, &list.Version)
+	})
+}
+
+// update applies the input to the list and describes the change.
+func (s *TodoListService) update(repos *repository.Repository, userId int, list todo.TodoList,
+	input todo.UpdateListInput, version *int) (todo.Activity, error) {
+	if err := repos.TodoList.Update(userId, list.Id, input, version); err != nil {
+		return todo.Activity{}, err
+	}
+
+	return todo.Activity{ListId: list.Id, Action: todo.ActivityUpdate, Changes: listChanges(list, input)}, nil
 }
 
-func (s *TodoListService) Update(userId, listId int, input todo.UpdateListInput) error {
+func (s *TodoListService) Move(userId, listId int, input todo.MoveListInput) error {
 	if err := input.Validate(); err != nil {
 		return err
 	}
 
-	return s.repo.Update(userId, listId, input)
-}
\ No newline at end of file
+	list, err := s.repo.GetById(userId, listId)
+	if err != nil {
+		return err
+	}
+
+	var position string
+	if input.AfterId != nil {
+		anchor, err := s.repo.GetById(userId, *input.AfterId)
+		if err != nil {
+			return err
+		}
+
+		next, err := s.repo.GetPositionAfter(userId, anchor.Position)
+		if err != nil {
+			return err
+		}
+
+		if position, err = rank.Between(anchor.Position, next); err != nil {
+			return err
+		}
+	} else {
+		anchor, err := s.repo.GetById(userId, *input.BeforeId)
+		if err != nil {
+			return err
+		}
+
+		prev, err := s.repo.GetPositionBefore(userId, anchor.Position)
+		if err != nil {
+			return err
+		}
+
+		if position, err = rank.Between(prev, anchor.Position); err != nil {
+			return err
+		}
+	}
+
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		if err := repos.TodoList.UpdatePosition(userId, listId, position); err != nil {
+			return todo.Activity{}, err
+		}
+
+		changes := make(todo.Changes)
+		addChange(changes, "position", list.Position, position)
+		return todo.Activity{ListId: listId, Action: todo.ActivityMove, Changes: changes}, nil
+	})
+}
+
+func (s *TodoListService) Archive(userId, listId int) error {
+	return s.setArchived(userId, listId, true)
+}
+
+func (s *TodoListService) Unarchive(userId, listId int) error {
+	return s.setArchived(userId, listId, false)
+}
+
+func (s *TodoListService) setArchived(userId, listId int, archived bool) error {
+	return record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		list, err := repos.TodoList.GetById(userId, listId)
+		if err != nil {
+			return todo.Activity{}, err
+		}
+
+		if err := repos.TodoList.SetArchived(userId, listId, archived); err != nil {
+			return todo.Activity{}, err
+		}
+
+		changes := make(todo.Changes)
+		addChange(changes, "archived", list.Archived, archived)
+		return todo.Activity{ListId: listId, Action: todo.ActivityUpdate, Changes: changes}, nil
+	})
+}
+
+func (s *TodoListService) Duplicate(userId, listId int, input todo.DuplicateListInput) (int, error) {
+	list, err := s.repo.GetById(userId, listId)
+	if err != nil {
+		return 0, err
+	}
+
+	items, err := s.itemRepo.GetTree(listId)
+	if err != nil {
+		return 0, err
+	}
+
+	if input.Title != nil {
+		list.Title = *input.Title
+	}
+
+	if input.ResetDone {
+		for i := range items {
+			items[i].Done = false
+		}
+	}
+
+	return s.createCopy(userId, list, items)
+}
+
+// Instantiate creates a regular list from the template, substituting
+// {{name}} placeholders in titles and descriptions.
+func (s *TodoListService) Instantiate(userId, listId int, input todo.InstantiateListInput) (int, error) {
+	list, err := s.repo.GetById(userId, listId)
+	if err != nil {
+		return 0, err
+	}
+
+	if !list.IsTemplate {
+		return 0, errors.New("list is not a template")
+	}
+
+	items, err := s.itemRepo.GetTree(listId)
+	if err != nil {
+		return 0, err
+	}
+
+	values := map[string]string{
+		"date": time.Now().Format(todo.DateLayout),
+	}
+	for name, value := range input.Values {
+		values[name] = value
+	}
+
+	pairs := make([]string, 0, len(values)*2)
+	for name, value := range values {
+		pairs = append(pairs, "{{"+name+"}}", value)
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	list.Title = replacer.Replace(list.Title)
+	list.Description = replacer.Replace(list.Description)
+	list.IsTemplate = false
+
+	for i := range items {
+		items[i].Title = replacer.Replace(items[i].Title)
+		items[i].Description = replacer.Replace(items[i].Description)
+		items[i].Done = false
+	}
+
+	return s.createCopy(userId, list, items)
+}
+
+func (s *TodoListService) createCopy(userId int, list todo.TodoList, items []todo.TodoItem) (int, error) {
+	last, err := s.repo.GetLastPosition(userId)
+	if err != nil {
+		return 0, err
+	}
+	list.Position = rank.After(last)
+
+	var id int
+	err = record(s.transactor, userId, func(repos *repository.Repository) (todo.Activity, error) {
+		var err error
+		if id, err = repos.TodoList.CreateWithItems(userId, list, items); err != nil {
+			return todo.Activity{}, err
+		}
+
+		return todo.Activity{ListId: id, Action: todo.ActivityCreate, Changes: listFields(list)}, nil
+	})
+
+	return id, err
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"time"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+type TrashService struct {
+	repo repository.Trash
+}
+
+func NewTrashService(repo repository.Trash) *TrashService {
+	return &TrashService{repo: repo}
+}
+
+func (s *TrashService) Get(userId int) (todo.Trash, error) {
+	lists, err := s.repo.GetLists(userId)
+	if err != nil {
+		return todo.Trash{}, err
+	}
+
+	items, err := s.repo.GetItems(userId)
+	if err != nil {
+		return todo.Trash{}, err
+	}
+
+	return todo.Trash{Lists: lists, Items: items}, nil
+}
+
+func (s *TrashService) Purge(before time.Time) error {
+	return s.repo.Purge(before)
+}
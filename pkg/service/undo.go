@@ -0,0 +1,270 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+// revertedValues holds the values of the fields restored by undoing a change.
+type revertedValues struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Done        *bool   `json:"done"`
+	DueDate     *string `json:"due_date"`
+	IsTemplate  *bool   `json:"is_template"`
+	Archived    *bool   `json:"archived"`
+	ListId      *int    `json:"list_id"`
+	ParentId    *int    `json:"parent_id"`
+	Position    *string `json:"position"`
+}
+
+// undoTarget identifies the list or, if itemId isn't zero, the item changed by an activity.
+type undoTarget struct {
+	listId, itemId int
+}
+
+func targetOf(activity todo.Activity) undoTarget {
+	if activity.ItemId != nil {
+		return undoTarget{itemId: *activity.ItemId}
+	}
+
+	return undoTarget{listId: activity.ListId}
+}
+
+type UndoService struct {
+	transactor repository.Transactor
+	window     time.Duration
+}
+
+func NewUndoService(transactor repository.Transactor, window time.Duration) *UndoService {
+	return &UndoService{transactor: transactor, window: window}
+}
+
+// Undo reverts the changes of the operation made within the undo window, newest first,
+// and returns their activity. Every revert is recorded as a new activity, so undoing
+// the undo operation redoes the changes. Nothing is reverted if any of the lists
+// or items has been changed since the operation. Only the newest change of a list
+// or an item is checked, the older ones are reverted on top of its revert.
+func (s *UndoService) Undo(userId int, input todo.UndoInput) ([]todo.Activity, error) {
+	var operation []todo.Activity
+	err := s.transactor.WithinTransaction(func(repos *repository.Repository) error {
+		var err error
+		operation, err = repos.Activity.GetOperation(userId, input.ActivityId, time.Now().Add(-s.window))
+		if err != nil {
+			return err
+		}
+
+		if len(operation) == 0 {
+			return todo.ErrNothingToUndo
+		}
+
+		undoneAt := time.Now()
+		revertedTargets := make(map[undoTarget]bool)
+		for i, activity := range operation {
+			reverted, err := s.revert(repos, userId, activity, !revertedTargets[targetOf(activity)])
+			if err != nil {
+				return err
+			}
+			revertedTargets[targetOf(activity)] = true
+
+			reverted.ActorId, reverted.Reverts = &userId, &operation[i].Id
+			if err := logActivity(repos, reverted); err != nil {
+				return err
+			}
+
+			if err := repos.Activity.SetUndone(activity.Id); err != nil {
+				return err
+			}
+			operation[i].UndoneAt = &undoneAt
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// revert applies the opposite of the change and describes it. If checkVersion is set,
+// the list or the item must still have the version it had after the change.
+func (s *UndoService) revert(repos *repository.Repository, userId int, activity todo.Activity, checkVersion bool) (todo.Activity, error) {
+	if activity.Version == nil {
+		// the change was made before versions were logged
+		return todo.Activity{}, todo.ErrUndoConflict
+	}
+
+	// nil version skips the checks
+	version := activity.Version
+	if !checkVersion {
+		version = nil
+	}
+
+	reverted := todo.Activity{ListId: activity.ListId, ItemId: activity.ItemId}
+	var err error
+	switch activity.Action {
+	case todo.ActivityCreate, todo.ActivityRestore:
+		reverted.Action = todo.ActivityDelete
+		err = s.delete(repos, userId, activity, version)
+	case todo.ActivityDelete:
+		reverted.Action = todo.ActivityRestore
+		err = s.restore(repos, userId, activity, version)
+	case todo.ActivityUpdate, todo.ActivityMove:
+		reverted.Action, reverted.Changes = activity.Action, activity.Changes.Revert()
+		err = s.update(repos, userId, activity, version, reverted.Changes)
+	default:
+		err = fmt.Errorf("can't undo %q", activity.Action)
+	}
+
+	if errors.Is(err, todo.ErrVersionMismatch) || errors.Is(err, sql.ErrNoRows) {
+		return todo.Activity{}, todo.ErrUndoConflict
+	}
+
+	return reverted, err
+}
+
+func (s *UndoService) delete(repos *repository.Repository, userId int, activity todo.Activity, version *int) error {
+	if activity.ItemId != nil {
+		return repos.TodoItem.Delete(userId, *activity.ItemId, version)
+	}
+
+	return repos.TodoList.Delete(userId, activity.ListId, version)
+}
+
+// restore takes the list or the item out of the trash. It must not have
+// been restored and deleted again since, which would change its version.
+func (s *UndoService) restore(repos *repository.Repository, userId int, activity todo.Activity, expected *int) error {
+	var version int
+	if activity.ItemId != nil {
+		if err := repos.TodoItem.Restore(userId, *activity.ItemId); err != nil {
+			return err
+		}
+
+		item, err := repos.TodoItem.GetById(userId, *activity.ItemId)
+		if err != nil {
+			return err
+		}
+		version = item.Version
+	} else {
+		if err := repos.TodoList.Restore(userId, activity.ListId); err != nil {
+			return err
+		}
+
+		list, err := repos.TodoList.GetById(userId, activity.ListId)
+		if err != nil {
+			return err
+		}
+		version = list.Version
+	}
+
+	// restoring increments the version
+	if expected != nil && version != *expected+1 {
+		return todo.ErrUndoConflict
+	}
+
+	return nil
+}
+
+// update sets the fields of the list or the item to the values from changes.
+func (s *UndoService) update(repos *repository.Repository, userId int, activity todo.Activity, version *int, changes todo.Changes) error {
+	var values revertedValues
+	if err := changes.Decode(&values); err != nil {
+		return err
+	}
+
+	if activity.ItemId != nil {
+		return s.updateItem(repos, userId, *activity.ItemId, version, changes, values)
+	}
+
+	return s.updateList(repos, userId, activity.ListId, version, values)
+}
+
+func (s *UndoService) updateList(repos *repository.Repository, userId, listId int, expected *int, values revertedValues) error {
+	list, err := repos.TodoList.GetById(userId, listId)
+	if err != nil {
+		return err
+	}
+
+	if expected != nil && list.Version != *expected {
+		return todo.ErrUndoConflict
+	}
+	version := list.Version
+
+	input := todo.UpdateListInput{Title: values.Title, Description: values.Description, IsTemplate: values.IsTemplate}
+	if input.Validate() == nil {
+		if err := repos.TodoList.Update(userId, listId, input, &version); err != nil {
+			return err
+		}
+	}
+
+	if values.Archived != nil {
+		if err := repos.TodoList.SetArchived(userId, listId, *values.Archived); err != nil {
+			return err
+		}
+	}
+
+	if values.Position != nil {
+		return repos.TodoList.UpdatePosition(userId, listId, *values.Position)
+	}
+
+	return nil
+}
+
+func (s *UndoService) updateItem(repos *repository.Repository, userId, itemId int, expected *int, changes todo.Changes, values revertedValues) error {
+	item, err := repos.TodoItem.GetById(userId, itemId)
+	if err != nil {
+		return err
+	}
+
+	if expected != nil && item.Version != *expected {
+		return todo.ErrUndoConflict
+	}
+	version := item.Version
+
+	input := todo.UpdateItemInput{Title: values.Title, Description: values.Description, Done: values.Done, DueDate: values.DueDate}
+	if input.Validate() == nil {
+		if err := repos.TodoItem.Update(userId, itemId, input, &version); err != nil {
+			return err
+		}
+	}
+
+	if values.Archived != nil {
+		if err := repos.TodoItem.SetArchived(userId, itemId, *values.Archived); err != nil {
+			return err
+		}
+	}
+
+	_, listChanged := changes["list_id"]
+	_, parentChanged := changes["parent_id"]
+	if listChanged || parentChanged {
+		listId, parentId, position := item.ListId, item.ParentId, item.Position
+		if values.ListId != nil {
+			listId = *values.ListId
+		}
+		if parentChanged {
+			parentId = values.ParentId
+		}
+		if values.Position != nil {
+			position = *values.Position
+		}
+
+		if _, err := repos.TodoList.GetById(userId, listId); err != nil {
+			// the list does not belong to user anymore
+			return err
+		}
+
+		return repos.TodoItem.MoveToList(userId, itemId, listId, parentId, position)
+	}
+
+	if values.Position != nil {
+		return repos.TodoItem.UpdatePosition(userId, itemId, *values.Position)
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/zhashkevych/todo-app"
+	"github.com/zhashkevych/todo-app/pkg/repository"
+)
+
+const (
+	webhookEventHeader     = "X-Todo-Event"
+	webhookDeliveryHeader  = "X-Todo-Delivery"
+	webhookSignatureHeader = "X-Todo-Signature"
+
+	webhookBatchSize  = 20
+	webhookLease      = time.Minute
+	webhookMaxBackoff = 6 * time.Hour
+)
+
+// errForbiddenAddress is returned for webhooks that resolve to an address of the internal network.
+var errForbiddenAddress = errors.New("webhook address is not allowed")
+
+// forbiddenNetworks are the private and shared networks webhooks can't be sent to,
+// besides loopback, link-local, multicast and unspecified addresses.
+var forbiddenNetworks = parseNetworks("0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12",
+	"192.168.0.0/16", "fc00::/7")
+
+var listEvents = map[string]string{
+	todo.ActivityCreate:  todo.EventListCreated,
+	todo.ActivityUpdate:  todo.EventListUpdated,
+	todo.ActivityMove:    todo.EventListMoved,
+	todo.ActivityDelete:  todo.EventListDeleted,
+	todo.ActivityRestore: todo.EventListRestored,
+}
+
+var itemEvents = map[string]string{
+	todo.ActivityCreate:  todo.EventItemCreated,
+	todo.ActivityUpdate:  todo.EventItemUpdated,
+	todo.ActivityMove:    todo.EventItemMoved,
+	todo.ActivityDelete:  todo.EventItemDeleted,
+	todo.ActivityRestore: todo.EventItemRestored,
+}
+
+type WebhookService struct {
+	repo        repository.Webhook
+	listRepo    repository.TodoList
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func NewWebhookService(repo repository.Webhook, listRepo repository.TodoList, timeout time.Duration,
+	maxAttempts int, backoff time.Duration) *WebhookService {
+	return &WebhookService{
+		repo:        repo,
+		listRepo:    listRepo,
+		client:      newWebhookClient(timeout),
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+	}
+}
+
+// newWebhookClient returns a client that only connects to public addresses. The address is
+// checked after the host is resolved, so a host can't be pointed to the internal network later.
+// Redirects aren't followed, the redirect response fails the delivery.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if !isPublicIP(net.ParseIP(host)) {
+				return errForbiddenAddress
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+
+	return networks
+}
+
+func (s *WebhookService) Create(userId int, webhook todo.Webhook) (int, error) {
+	if webhook.ListId != nil {
+		if _, err := s.listRepo.GetById(userId, *webhook.ListId); err != nil {
+			// list does not exists or does not belongs to user
+			return 0, err
+		}
+	}
+
+	return s.repo.Create(userId, webhook)
+}
+
+func (s *WebhookService) GetAll(userId int) ([]todo.Webhook, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *WebhookService) GetById(userId, webhookId int) (todo.Webhook, error) {
+	return s.repo.GetById(userId, webhookId)
+}
+
+func (s *WebhookService) Delete(userId, webhookId int) error {
+	return s.repo.Delete(userId, webhookId)
+}
+
+func (s *WebhookService) GetDeliveries(userId, webhookId int) ([]todo.WebhookDelivery, error) {
+	return s.repo.GetDeliveries(userId, webhookId)
+}
+
+func (s *WebhookService) Replay(userId, webhookId, deliveryId int) (int, error) {
+	return s.repo.Replay(userId, webhookId, deliveryId)
+}
+
+// DeliverPending sends the deliveries that are due until there are none left.
+func (s *WebhookService) DeliverPending() error {
+	for {
+		deliveries, err := s.repo.Claim(webhookBatchSize, webhookLease)
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			if err := s.deliver(delivery); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < webhookBatchSize {
+			return nil
+		}
+	}
+}
+
+// deliver sends the delivery and records the outcome of the attempt. Failed deliveries are
+// retried with exponential backoff until they run out of attempts.
+func (s *WebhookService) deliver(delivery todo.PendingDelivery) error {
+	status, err := s.send(delivery)
+	if err == nil {
+		return s.repo.Complete(delivery.Id, status)
+	}
+
+	var responseStatus *int
+	if status != 0 {
+		responseStatus = &status
+	}
+
+	var nextAttemptAt *time.Time
+	if attempts := delivery.Attempts + 1; attempts < s.maxAttempts {
+		next := time.Now().Add(s.retryDelay(attempts))
+		nextAttemptAt = &next
+	}
+
+	return s.repo.Fail(delivery.Id, responseStatus, err.Error(), nextAttemptAt)
+}
+
+// send posts the payload signed with the webhook's secret and returns the response status.
+// Any status other than 2xx is an error.
+func (s *WebhookService) send(delivery todo.PendingDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, delivery.Event)
+	req.Header.Set(webhookDeliveryHeader, strconv.Itoa(delivery.Id))
+	req.Header.Set(webhookSignatureHeader, "sha256="+sign(delivery.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryDelay doubles the backoff with every failed attempt.
+func (s *WebhookService) retryDelay(attempts int) time.Duration {
+	return backoffDelay(s.backoff, attempts, webhookMaxBackoff)
+}
+
+// backoffDelay doubles base with every attempt after the first one, up to max.
+func backoffDelay(base time.Duration, attempts int, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+
+	if delay > max {
+		return max
+	}
+
+	return delay
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the payload, which receivers
+// compare with the X-Todo-Signature header to verify the delivery.
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// enqueueWebhooks schedules the deliveries of the events of the activity within the transaction
+// the activity is recorded in, so webhooks are called only for changes that were committed.
+func enqueueWebhooks(repos *repository.Repository, activity todo.Activity) error {
+	for _, event := range webhookEvents(activity) {
+		payload, err := json.Marshal(todo.WebhookEvent{
+			Event:      event,
+			ListId:     activity.ListId,
+			ItemId:     activity.ItemId,
+			ActorId:    activity.ActorId,
+			Changes:    activity.Changes,
+			OccurredAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Webhook.Enqueue(event, activity.ListId, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func webhookEvents(activity todo.Activity) []string {
+	if activity.ItemId == nil {
+		return []string{listEvents[activity.Action]}
+	}
+
+	events := []string{itemEvents[activity.Action]}
+	if done, ok := activity.Changes["done"]; ok && done.To == true {
+		events = append(events, todo.EventItemCompleted)
+	}
+
+	return events
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app/pkg/service"
+)
+
+// listenerPingInterval checks the listener's connection when there are no notifications.
+const listenerPingInterval = 90 * time.Second
+
+// ActivityRelay passes the notifications about recorded activity to the streams of this instance.
+type ActivityRelay struct {
+	listener *pq.Listener
+	service  service.Stream
+}
+
+func NewActivityRelay(listener *pq.Listener, service service.Stream) *ActivityRelay {
+	return &ActivityRelay{listener: listener, service: service}
+}
+
+// Run relays the notifications until ctx is cancelled.
+func (r *ActivityRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-r.listener.Notify:
+			if notification == nil {
+				// the connection was re-established, notifications might have been missed
+				r.service.NotifyAll()
+				continue
+			}
+
+			listId, err := strconv.Atoi(notification.Extra)
+			if err != nil {
+				logrus.Errorf("invalid activity notification: %s", notification.Extra)
+				continue
+			}
+
+			if err := r.service.Notify(listId); err != nil {
+				logrus.Errorf("error occured while notifying streams: %s", err.Error())
+			}
+		case <-ticker.C:
+			go r.listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app/pkg/service"
+)
+
+// OutboxRelay publishes the events written to the outbox.
+type OutboxRelay struct {
+	service  service.Outbox
+	interval time.Duration
+}
+
+func NewOutboxRelay(service service.Outbox, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{service: service, interval: interval}
+}
+
+// Run publishes the due events every interval until ctx is cancelled.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.service.PublishPending(); err != nil {
+			logrus.Errorf("error occured while publishing outbox events: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
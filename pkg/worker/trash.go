@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app/pkg/service"
+)
+
+// TrashPurger permanently removes lists and items that stayed
+// in the trash longer than the retention period.
+type TrashPurger struct {
+	service   service.Trash
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewTrashPurger(service service.Trash, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{service: service, retention: retention, interval: interval}
+}
+
+// Run purges the trash every interval until ctx is cancelled.
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.service.Purge(time.Now().Add(-p.retention)); err != nil {
+			logrus.Errorf("error occured while purging trash: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
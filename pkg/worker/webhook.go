@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zhashkevych/todo-app/pkg/service"
+)
+
+// WebhookDispatcher sends pending webhook deliveries.
+type WebhookDispatcher struct {
+	service  service.Webhook
+	interval time.Duration
+}
+
+func NewWebhookDispatcher(service service.Webhook, interval time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{service: service, interval: interval}
+}
+
+// Run delivers the due webhooks every interval until ctx is cancelled.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.service.DeliverPending(); err != nil {
+			logrus.Errorf("error occured while delivering webhooks: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
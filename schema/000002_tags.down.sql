@@ -0,0 +1,3 @@
+DROP TABLE items_tags;
+
+DROP TABLE tags;
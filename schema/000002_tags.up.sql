@@ -0,0 +1,15 @@
+CREATE TABLE tags
+(
+    id      serial                                      not null unique,
+    user_id int references users (id) on delete cascade not null,
+    name    varchar(255)                                not null,
+    unique (user_id, name)
+);
+
+CREATE TABLE items_tags
+(
+    id      serial                                           not null unique,
+    item_id int references todo_items (id) on delete cascade not null,
+    tag_id  int references tags (id) on delete cascade       not null,
+    unique (item_id, tag_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE todo_items
+    ADD COLUMN parent_id int references todo_items (id) on delete cascade;
@@ -0,0 +1,11 @@
+ALTER TABLE todo_lists
+    ADD COLUMN position text collate "C" not null default '';
+
+ALTER TABLE todo_items
+    ADD COLUMN position text collate "C" not null default '';
+
+UPDATE todo_lists
+SET position = lpad(id::text, 10, '0') || 'i';
+
+UPDATE todo_items
+SET position = lpad(id::text, 10, '0') || 'i';
@@ -0,0 +1,5 @@
+ALTER TABLE todo_items
+    DROP COLUMN archived;
+
+ALTER TABLE todo_lists
+    DROP COLUMN archived;
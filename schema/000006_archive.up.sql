@@ -0,0 +1,5 @@
+ALTER TABLE todo_lists
+    ADD COLUMN archived boolean not null default false;
+
+ALTER TABLE todo_items
+    ADD COLUMN archived boolean not null default false;
@@ -0,0 +1,5 @@
+ALTER TABLE todo_lists
+    ADD COLUMN deleted_at timestamp;
+
+ALTER TABLE todo_items
+    ADD COLUMN deleted_at timestamp;
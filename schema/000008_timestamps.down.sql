@@ -0,0 +1,12 @@
+ALTER TABLE todo_items
+    DROP COLUMN created_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_by,
+    DROP COLUMN updated_by,
+    DROP COLUMN completed_at;
+
+ALTER TABLE todo_lists
+    DROP COLUMN created_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_by,
+    DROP COLUMN updated_by;
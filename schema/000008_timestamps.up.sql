@@ -0,0 +1,16 @@
+ALTER TABLE todo_lists
+    ADD COLUMN created_at timestamp not null default now(),
+    ADD COLUMN updated_at timestamp not null default now(),
+    ADD COLUMN created_by int references users (id) on delete set null,
+    ADD COLUMN updated_by int references users (id) on delete set null;
+
+ALTER TABLE todo_items
+    ADD COLUMN created_at   timestamp not null default now(),
+    ADD COLUMN updated_at   timestamp not null default now(),
+    ADD COLUMN created_by   int references users (id) on delete set null,
+    ADD COLUMN updated_by   int references users (id) on delete set null,
+    ADD COLUMN completed_at timestamp;
+
+UPDATE todo_items
+SET completed_at = now()
+WHERE done;
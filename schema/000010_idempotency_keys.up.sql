@@ -0,0 +1,11 @@
+CREATE TABLE idempotency_keys
+(
+    user_id          int references users (id) on delete cascade not null,
+    key              varchar(255)                                not null,
+    request_hash     varchar(64)                                 not null,
+    status_code      int,
+    response_body    bytea,
+    response_headers jsonb,
+    created_at       timestamp                                   not null default now(),
+    unique (user_id, key)
+);
@@ -0,0 +1 @@
+DROP TABLE activity_log;
@@ -0,0 +1,12 @@
+CREATE TABLE activity_log
+(
+    id         serial                                           not null unique,
+    actor_id   int references users (id) on delete set null,
+    list_id    int references todo_lists (id) on delete cascade not null,
+    item_id    int,
+    action     varchar(16)                                      not null,
+    changes    jsonb,
+    created_at timestamp                                        not null default now()
+);
+
+CREATE INDEX activity_log_list_id_idx ON activity_log (list_id, id);
@@ -0,0 +1,7 @@
+DROP INDEX activity_log_actor_id_idx;
+
+ALTER TABLE activity_log
+    DROP COLUMN version,
+    DROP COLUMN transaction_id,
+    DROP COLUMN reverts,
+    DROP COLUMN undone_at;
@@ -0,0 +1,7 @@
+ALTER TABLE activity_log
+    ADD COLUMN version        int,
+    ADD COLUMN transaction_id bigint not null default txid_current(),
+    ADD COLUMN reverts        int references activity_log (id) on delete set null,
+    ADD COLUMN undone_at      timestamp;
+
+CREATE INDEX activity_log_actor_id_idx ON activity_log (actor_id, id);
@@ -0,0 +1,27 @@
+CREATE TABLE webhooks
+(
+    id         serial                                           not null unique,
+    user_id    int references users (id) on delete cascade      not null,
+    list_id    int references todo_lists (id) on delete cascade,
+    url        varchar(2048)                                    not null,
+    secret     varchar(255)                                     not null,
+    events     varchar(64)[]                                    not null default '{}',
+    created_at timestamp                                        not null default now()
+);
+
+CREATE TABLE webhook_deliveries
+(
+    id              serial                                         not null unique,
+    webhook_id      int references webhooks (id) on delete cascade not null,
+    event           varchar(64)                                    not null,
+    payload         jsonb                                          not null,
+    status          varchar(16)                                    not null default 'pending',
+    attempts        int                                            not null default 0,
+    response_status int,
+    error           text,
+    next_attempt_at timestamp                                      not null default now(),
+    delivered_at    timestamp,
+    created_at      timestamp                                      not null default now()
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
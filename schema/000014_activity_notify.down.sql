@@ -0,0 +1,5 @@
+DROP INDEX activity_log_transaction_id_idx;
+
+DROP TRIGGER activity_log_notify ON activity_log;
+
+DROP FUNCTION notify_activity();
@@ -0,0 +1,15 @@
+CREATE FUNCTION notify_activity() RETURNS trigger AS
+$$
+BEGIN
+    PERFORM pg_notify('activity', NEW.list_id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER activity_log_notify
+    AFTER INSERT
+    ON activity_log
+    FOR EACH ROW
+EXECUTE PROCEDURE notify_activity();
+
+CREATE INDEX activity_log_transaction_id_idx ON activity_log (transaction_id, id);
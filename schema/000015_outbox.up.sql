@@ -0,0 +1,15 @@
+CREATE TABLE outbox
+(
+    id              serial       not null unique,
+    event           varchar(64)  not null,
+    list_id         int          not null,
+    item_id         int,
+    payload         jsonb        not null,
+    attempts        int          not null default 0,
+    error           text,
+    next_attempt_at timestamp    not null default now(),
+    published_at    timestamp,
+    created_at      timestamp    not null default now()
+);
+
+CREATE INDEX outbox_unpublished_idx ON outbox (next_attempt_at, id) WHERE published_at IS NULL;
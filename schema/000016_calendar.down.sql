@@ -0,0 +1,5 @@
+ALTER TABLE users DROP COLUMN calendar_token;
+
+DROP INDEX todo_items_due_date_idx;
+
+ALTER TABLE todo_items DROP COLUMN due_date;
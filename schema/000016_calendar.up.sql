@@ -0,0 +1,5 @@
+ALTER TABLE todo_items ADD COLUMN due_date date;
+
+CREATE INDEX todo_items_due_date_idx ON todo_items (due_date) WHERE due_date IS NOT NULL AND deleted_at IS NULL;
+
+ALTER TABLE users ADD COLUMN calendar_token varchar(64) unique;
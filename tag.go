@@ -0,0 +1,26 @@
+package todo
+
+import "errors"
+
+type Tag struct {
+	Id   int    `json:"id" db:"id"`
+	Name string `json:"name" db:"name" binding:"required"`
+}
+
+type ItemsTag struct {
+	Id     int
+	ItemId int
+	TagId  int
+}
+
+type UpdateTagInput struct {
+	Name *string `json:"name"`
+}
+
+func (i UpdateTagInput) Validate() error {
+	if i.Name == nil {
+		return errors.New("update structure has no values")
+	}
+
+	return nil
+}
@@ -1,11 +1,31 @@
 package todo
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the format of dates without time, such as due dates.
+const DateLayout = "2006-01-02"
+
+// ErrVersionMismatch is returned by conditional updates when the resource
+// has been modified since the version the client has seen.
+var ErrVersionMismatch = errors.New("resource has been modified")
 
 type TodoList struct {
-	Id          int    `json:"id" db:"id"`
-	Title       string `json:"title" db:"title" binding:"required"`
-	Description string `json:"description" db:"description"`
+	Id          int        `json:"id" db:"id"`
+	Title       string     `json:"title" db:"title" binding:"required"`
+	Description string     `json:"description" db:"description"`
+	Position    string     `json:"position" db:"position"`
+	IsTemplate  bool       `json:"is_template" db:"is_template"`
+	Archived    bool       `json:"archived" db:"archived"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	CreatedBy   *int       `json:"created_by" db:"created_by"`
+	UpdatedBy   *int       `json:"updated_by" db:"updated_by"`
+	Version     int        `json:"version" db:"version"`
 }
 
 type UsersList struct {
@@ -15,10 +35,29 @@ type UsersList struct {
 }
 
 type TodoItem struct {
-	Id          int    `json:"id" db:"id"`
-	Title       string `json:"title" db:"title" binding:"required"`
-	Description string `json:"description" db:"description"`
-	Done        bool   `json:"done" db:"done"`
+	Id          int           `json:"id" db:"id"`
+	Title       string        `json:"title" db:"title" binding:"required"`
+	Description string        `json:"description" db:"description"`
+	Done        bool          `json:"done" db:"done"`
+	ListId      int           `json:"list_id" db:"list_id"`
+	ParentId    *int          `json:"parent_id" db:"parent_id"`
+	Position    string        `json:"position" db:"position"`
+	Archived    bool          `json:"archived" db:"archived"`
+	DeletedAt   *time.Time    `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
+	CreatedBy   *int          `json:"created_by" db:"created_by"`
+	UpdatedBy   *int          `json:"updated_by" db:"updated_by"`
+	CompletedAt *time.Time    `json:"completed_at" db:"completed_at"`
+	DueDate     *string       `json:"due_date" db:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	Version     int           `json:"version" db:"version"`
+	Progress    *ItemProgress `json:"progress,omitempty" db:"-"`
+}
+
+// ItemProgress counts the direct subtasks of an item.
+type ItemProgress struct {
+	Total int `json:"total" db:"total"`
+	Done  int `json:"done" db:"done"`
 }
 
 type ListsItem struct {
@@ -27,29 +66,110 @@ type ListsItem struct {
 	ItemId int
 }
 
+// Page selects a page of a collection. A zero limit selects the whole collection.
+type Page struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ListFilter and ItemFilter accept a sort field of created_at or updated_at,
+// prefixed with "-" for descending order. Manual order is used by default.
+type ListFilter struct {
+	Page
+	IncludeArchived bool   `form:"include_archived"`
+	Sort            string `form:"sort" binding:"omitempty,oneof=created_at -created_at updated_at -updated_at"`
+}
+
+type ItemFilter struct {
+	Page
+	Tag             string `form:"tag"`
+	IncludeArchived bool   `form:"include_archived"`
+	Sort            string `form:"sort" binding:"omitempty,oneof=created_at -created_at updated_at -updated_at"`
+}
+
 type UpdateListInput struct {
 	Title       *string `json:"title"`
 	Description *string `json:"description"`
+	IsTemplate  *bool   `json:"is_template"`
 }
 
 func (i UpdateListInput) Validate() error {
-	if i.Title == nil && i.Description == nil {
+	if i.Title == nil && i.Description == nil && i.IsTemplate == nil {
 		return errors.New("update structure has no values")
 	}
 
 	return nil
 }
 
+// UpdateItemInput sets the due date as YYYY-MM-DD, an empty DueDate removes it.
 type UpdateItemInput struct {
 	Title       *string `json:"title"`
 	Description *string `json:"description"`
 	Done        *bool   `json:"done"`
+	DueDate     *string `json:"due_date"`
 }
 
 func (i UpdateItemInput) Validate() error {
-	if i.Title == nil && i.Description == nil && i.Done == nil {
+	if i.Title == nil && i.Description == nil && i.Done == nil && i.DueDate == nil {
 		return errors.New("update structure has no values")
 	}
 
+	if i.DueDate != nil && *i.DueDate != "" {
+		if _, err := time.Parse(DateLayout, *i.DueDate); err != nil {
+			return fmt.Errorf("invalid due_date %q", *i.DueDate)
+		}
+	}
+
 	return nil
-}
\ No newline at end of file
+}
+
+// MoveListInput places a list right before or right after another list of the user.
+type MoveListInput struct {
+	BeforeId *int `json:"before_id"`
+	AfterId  *int `json:"after_id"`
+}
+
+func (i MoveListInput) Validate() error {
+	if (i.BeforeId == nil) == (i.AfterId == nil) {
+		return errors.New("exactly one of before_id and after_id must be set")
+	}
+
+	return nil
+}
+
+// DuplicateListInput overrides the title of the copy and optionally marks all copied items as not done.
+type DuplicateListInput struct {
+	Title     *string `json:"title"`
+	ResetDone bool    `json:"reset_done"`
+}
+
+// InstantiateListInput holds values for the {{name}} placeholders of a template.
+// The {{date}} placeholder defaults to the current date.
+type InstantiateListInput struct {
+	Values map[string]string `json:"values"`
+}
+
+// MoveItemInput places an item right before or right after one of its siblings.
+// When ListId is set the item with its subtasks is moved to the root of that list,
+// at the end unless an anchor is given.
+type MoveItemInput struct {
+	ListId   *int `json:"list_id"`
+	BeforeId *int `json:"before_id"`
+	AfterId  *int `json:"after_id"`
+}
+
+func (i MoveItemInput) Validate() error {
+	if i.BeforeId != nil && i.AfterId != nil {
+		return errors.New("before_id and after_id can't be set together")
+	}
+
+	if i.ListId == nil && i.BeforeId == nil && i.AfterId == nil {
+		return errors.New("move structure has no values")
+	}
+
+	return nil
+}
+
+type CopyItemInput struct {
+	ListId int `json:"list_id" binding:"required"`
+}
@@ -0,0 +1,6 @@
+package todo
+
+type Trash struct {
+	Lists []TodoList `json:"lists"`
+	Items []TodoItem `json:"items"`
+}
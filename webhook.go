@@ -0,0 +1,72 @@
+package todo
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	EventListCreated   = "list.created"
+	EventListUpdated   = "list.updated"
+	EventListMoved     = "list.moved"
+	EventListDeleted   = "list.deleted"
+	EventListRestored  = "list.restored"
+	EventItemCreated   = "item.created"
+	EventItemUpdated   = "item.updated"
+	EventItemCompleted = "item.completed"
+	EventItemMoved     = "item.moved"
+	EventItemDeleted   = "item.deleted"
+	EventItemRestored  = "item.restored"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Webhook receives the events of the list or, if ListId is nil, of all lists of the user.
+// Empty Events subscribes to all events. The secret is never returned.
+type Webhook struct {
+	Id        int            `json:"id" db:"id"`
+	ListId    *int           `json:"list_id" db:"list_id"`
+	Url       string         `json:"url" db:"url" binding:"required,url,max=2048"`
+	Secret    string         `json:"secret,omitempty" db:"secret" binding:"required,min=16,max=255"`
+	Events    pq.StringArray `json:"events" db:"events" binding:"dive,oneof=list.created list.updated list.moved list.deleted list.restored item.created item.updated item.completed item.moved item.deleted item.restored"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// WebhookEvent is the payload of a delivery.
+type WebhookEvent struct {
+	Event      string    `json:"event"`
+	ListId     int       `json:"list_id"`
+	ItemId     *int      `json:"item_id,omitempty"`
+	ActorId    *int      `json:"actor_id"`
+	Changes    Changes   `json:"changes,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// WebhookDelivery is an entry of the delivery log of a webhook. Pending deliveries
+// are retried until they succeed or run out of attempts.
+type WebhookDelivery struct {
+	Id             int             `json:"id" db:"id"`
+	WebhookId      int             `json:"webhook_id" db:"webhook_id"`
+	Event          string          `json:"event" db:"event"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	ResponseStatus *int            `json:"response_status" db:"response_status"`
+	Error          *string         `json:"error" db:"error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at" db:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// PendingDelivery is a delivery due to be sent along with the webhook's URL and secret.
+type PendingDelivery struct {
+	WebhookDelivery
+	Url    string `db:"url"`
+	Secret string `db:"secret"`
+}